@@ -22,6 +22,11 @@ type Missing struct{}
 // Equivalent to 'WHERE true'
 type MatchAll struct{}
 
+// FullTable Condition; marks that UPDATE, DELETE may affect all rows of the table
+type FullTable struct {
+	condition Condition
+}
+
 // Value Condition, uses KeyValue (one value)
 type Value struct {
 	pair     *rdb.Value
@@ -35,12 +40,23 @@ type List struct {
 	soloOperator string
 }
 
+// Range Condition, uses KeyList (low and high values)
+type Range struct {
+	pair     *rdb.List
+	operator string
+}
+
 // Multi Condition for joining multiple conditions through AND, OR
 type Multi struct {
 	conditions []Condition // Multiple conditions
 	operator   string      // Join operator
 }
 
+// Negation Condition
+type Negation struct {
+	condition Condition
+}
+
 // Build Missing condition
 func (c Missing) Build() (string, []any) {
 	return falseConditionValues()
@@ -51,6 +67,37 @@ func (c MatchAll) Build() (string, []any) {
 	return trueConditionValues()
 }
 
+// Create FullTable condition, wrapping condition (nil = match all)
+func NewFullTable(c Condition) *FullTable {
+	return &FullTable{condition: c}
+}
+
+// Build FullTable condition
+func (c FullTable) Build() (string, []any) {
+	if c.condition == nil {
+		return trueConditionValues()
+	}
+	return c.condition.Build()
+}
+
+// Check if condition matches all rows (builds to 'true')
+func IsMatchAll(c Condition) bool {
+	if c == nil {
+		return false
+	}
+	condition, _ := c.Build()
+	return condition == trueCondition
+}
+
+// Check if condition is the FullTable marker
+func IsFullTable(c Condition) bool {
+	switch c.(type) {
+	case FullTable, *FullTable:
+		return true
+	}
+	return false
+}
+
 // Build Value condition
 func (c Value) Build() (string, []any) {
 	if c.pair == nil {
@@ -83,49 +130,154 @@ func (c List) Build() (string, []any) {
 	}
 }
 
-// Build Multi condition
+// Build Range condition
+func (c Range) Build() (string, []any) {
+	if c.pair == nil {
+		// no pair = false condition
+		return falseConditionValues()
+	}
+	column, values := c.pair.Tuple()
+	if column == "" || len(values) != 2 {
+		// no column or incomplete range = false condition
+		return falseConditionValues()
+	}
+	return rangeCondition(column, c.operator), values
+}
+
+// Build Multi condition, simplifying true and false conditions:
+// AND: false if any condition is false, true conditions are dropped;
+// OR: true if any condition is true, false conditions are dropped
 func (c Multi) Build() (string, []any) {
 	numConditions := len(c.conditions)
-	switch numConditions {
-	case 0:
+	conditions := make([]string, 0, numConditions)
+	allValues := make([]any, 0)
+	numBuilt := 0
+	for _, condition := range c.conditions {
+		if condition == nil {
+			continue // skip null conditions
+		}
+		numBuilt += 1
+		conditionString, values := condition.Build()
+		switch {
+		case c.operator == And && conditionString == falseCondition:
+			return falseConditionValues()
+		case c.operator == Or && conditionString == trueCondition:
+			return trueConditionValues()
+		case c.operator == And && conditionString == trueCondition:
+			continue // no effect on AND
+		case c.operator == Or && conditionString == falseCondition:
+			continue // no effect on OR
+		}
+		conditions = append(conditions, conditionString)
+		allValues = append(allValues, values...)
+	}
+	switch {
+	case numBuilt == 0:
 		// no conditions = false condition
 		return falseConditionValues()
-	case 1:
-		// one condition = only build that one
-		return c.conditions[0].Build()
-	default:
-		conditions := make([]string, 0, numConditions)
-		allValues := make([]any, 0)
-		for _, condition := range c.conditions {
-			if condition == nil {
-				continue // skip null conditions
-			}
-			conditionString, values := condition.Build()
-			if conditionString == falseCondition {
-				// If any condition fails, return false condition immediately
-				return falseConditionValues()
-			}
-			conditions = append(conditions, conditionString)
-			allValues = append(allValues, values...)
+	case len(conditions) == 0:
+		// all conditions dropped: AND of trues = true, OR of falses = false
+		if c.operator == And {
+			return trueConditionValues()
 		}
-		// Join by operator and wrap in parentheses
-		glue := fmt.Sprintf(" %s ", c.operator)
-		fullCondition := fmt.Sprintf("(%s)", strings.Join(conditions, glue))
-		return fullCondition, allValues
+		return falseConditionValues()
+	case len(conditions) == 1:
+		// one condition = only that one
+		return conditions[0], allValues
+	}
+	// Join by operator and wrap in parentheses
+	glue := fmt.Sprintf(" %s ", c.operator)
+	fullCondition := fmt.Sprintf("(%s)", strings.Join(conditions, glue))
+	return fullCondition, allValues
+}
+
+// Build Negation condition
+func (c Negation) Build() (string, []any) {
+	if c.condition == nil {
+		// no condition = false condition
+		return falseConditionValues()
+	}
+	condition, values := c.condition.Build()
+	switch condition {
+	case falseCondition:
+		return trueConditionValues()
+	case trueCondition:
+		return falseConditionValues()
+	}
+	if !strings.HasPrefix(condition, "(") || !strings.HasSuffix(condition, ")") {
+		condition = fmt.Sprintf("(%s)", condition) // Multi conditions are already wrapped
 	}
+	return fmt.Sprintf("NOT %s", condition), values
 }
 
 // Create new Value condition
 func NewValue[T any](fieldRef *T, value T, operator string) *Value {
+	if isEqualityOperator(operator) && rdb.IsKeyNullZero(fieldRef, value) {
+		// zero value of nullzero column is stored as NULL
+		return NewNullValue(fieldRef, nullOperator(operator))
+	}
 	return &Value{rdb.KeyValue(fieldRef, value), operator}
 }
 
+// Create new Value condition with no value (IS NULL, IS NOT NULL)
+func NewNullValue(fieldRef any, operator string) *Value {
+	return &Value{rdb.KeyNull(fieldRef), operator}
+}
+
+// Create new Range condition
+func NewRange[T any](fieldRef *T, low, high T, operator string) *Range {
+	return &Range{rdb.KeyList(fieldRef, []T{low, high}), operator}
+}
+
 // Create new List condition
 func NewList[T any](fieldRef *T, values []T, listOperator, soloOperator string) *List {
 	return &List{rdb.KeyList(fieldRef, values), listOperator, soloOperator}
 }
 
+// Create new Value condition, using type's field name
+func NewFieldValue(typeName, fieldName string, value any, operator string) *Value {
+	if isEqualityOperator(operator) && rdb.IsColumnNullZero(typeName, fieldName, value) {
+		// zero value of nullzero column is stored as NULL
+		return NewFieldNullValue(typeName, fieldName, nullOperator(operator))
+	}
+	return &Value{rdb.ColumnValue(typeName, fieldName, value), operator}
+}
+
+// Create new Value condition with no value (IS NULL, IS NOT NULL), using type's field name
+func NewFieldNullValue(typeName, fieldName, operator string) *Value {
+	pair := rdb.ColumnValue(typeName, fieldName, nil)
+	return &Value{pair, operator}
+}
+
+// Create new List condition, using type's field name
+func NewFieldList(typeName, fieldName string, values []any, listOperator, soloOperator string) *List {
+	return &List{rdb.ColumnList(typeName, fieldName, values), listOperator, soloOperator}
+}
+
+// Create new Range condition, using type's field name
+func NewFieldRange(typeName, fieldName string, low, high any, operator string) *Range {
+	return &Range{rdb.ColumnList(typeName, fieldName, []any{low, high}), operator}
+}
+
 // Create new Multi condition
 func NewMulti(operator string, conditions ...Condition) *Multi {
 	return &Multi{conditions, operator}
 }
+
+// Create new Negation condition
+func NewNegation(condition Condition) *Negation {
+	return &Negation{condition}
+}
+
+// Check if operator is Equal or NotEqual, which compare nullzero zero values with NULL
+func isEqualityOperator(operator string) bool {
+	return operator == Equal || operator == NotEqual
+}
+
+// Get IS NULL / IS NOT NULL operator for Equal / NotEqual
+func nullOperator(operator string) string {
+	if operator == NotEqual {
+		return IsNotNull
+	}
+	return IsNull
+}
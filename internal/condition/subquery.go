@@ -0,0 +1,55 @@
+package condition
+
+import (
+	"fmt"
+
+	"github.com/roidaradal/rdb/internal/rdb"
+)
+
+// Subquery used inside a condition; same interface as query.Query
+type Query interface {
+	Build() (string, []any) // Return (query string, parameter values)
+}
+
+// Subquery Condition: column IN (subquery), EXISTS (subquery), column = (subquery)
+type Subquery struct {
+	column   string // blank for EXISTS, NOT EXISTS
+	query    Query
+	operator string
+}
+
+// Build Subquery condition, merging the subquery's parameter values
+func (c Subquery) Build() (string, []any) {
+	if c.query == nil {
+		// no subquery = false condition
+		return falseConditionValues()
+	}
+	if v, ok := c.query.(interface{ Validate() error }); ok && v.Validate() != nil {
+		// invalid subquery = false condition
+		return falseConditionValues()
+	}
+	subquery, values := c.query.Build()
+	if subquery == "" {
+		// failed subquery = false condition
+		return falseConditionValues()
+	}
+	values = append([]any{}, values...)
+	if c.operator == Exists || c.operator == NotExists {
+		return fmt.Sprintf("%s (%s)", c.operator, subquery), values
+	}
+	if c.column == "" {
+		// no column = false condition
+		return falseConditionValues()
+	}
+	return fmt.Sprintf("%s %s (%s)", c.column, c.operator, subquery), values
+}
+
+// Create new Subquery condition on field: IN, NOT IN, and comparison operators
+func NewSubquery(fieldRef any, q Query, operator string) *Subquery {
+	return &Subquery{rdb.GetColumnName(fieldRef), q, operator}
+}
+
+// Create new Exists (or NotExists) Subquery condition
+func NewExists(q Query, operator string) *Subquery {
+	return &Subquery{"", q, operator}
+}
@@ -0,0 +1,137 @@
+package condition
+
+import (
+	"strings"
+
+	"github.com/roidaradal/rdb/internal/dialect"
+)
+
+// Condition with child conditions: Multi, Negation
+type Node interface {
+	Condition
+	Operator() string      // AND, OR, NOT
+	Children() []Condition // child conditions
+}
+
+// Condition on one column: Value, List, Range
+type Leaf interface {
+	Condition
+	Parts() (column string, operator string, values []any) // original Go values; column is blank if field is unknown
+}
+
+// Return Value's column, operator and original Go value (none for IS NULL, IS NOT NULL)
+func (c Value) Parts() (string, string, []any) {
+	if c.pair == nil {
+		return "", c.operator, []any{}
+	}
+	column, value := c.pair.RawTuple()
+	if c.operator == IsNull || c.operator == IsNotNull {
+		return column, c.operator, []any{}
+	}
+	return column, c.operator, []any{value}
+}
+
+// Return List's column, list operator and original Go values
+func (c List) Parts() (string, string, []any) {
+	if c.pair == nil {
+		return "", c.listOperator, []any{}
+	}
+	column, values := c.pair.RawTuple()
+	return column, c.listOperator, values
+}
+
+// Return Range's column, operator and original Go low, high values
+func (c Range) Parts() (string, string, []any) {
+	if c.pair == nil {
+		return "", c.operator, []any{}
+	}
+	column, values := c.pair.RawTuple()
+	return column, c.operator, values
+}
+
+// Return Multi's join operator
+func (c Multi) Operator() string {
+	return c.operator
+}
+
+// Return Multi's conditions
+func (c Multi) Children() []Condition {
+	return c.conditions
+}
+
+// Return Negation's operator
+func (c Negation) Operator() string {
+	return Not
+}
+
+// Return negated condition
+func (c Negation) Children() []Condition {
+	return []Condition{c.condition}
+}
+
+// Visit condition tree in depth-first order;
+// Return false from visit function to skip the condition's children
+func Walk(c Condition, visit func(c Condition, depth int) bool) {
+	walk(c, 0, visit)
+}
+
+// Recursive walk, with tree depth
+func walk(c Condition, depth int, visit func(Condition, int) bool) {
+	if !visit(c, depth) {
+		return
+	}
+	if node, ok := c.(Node); ok {
+		for _, child := range node.Children() {
+			walk(child, depth+1, visit)
+		}
+	}
+}
+
+// Flatten condition tree: nested Multi conditions with the same operator are merged,
+// null conditions are removed, and double negations are cancelled;
+// empty Multi and Not(nil) are kept, as they are false conditions
+func Flatten(c Condition) Condition {
+	node, ok := c.(Node)
+	if !ok {
+		return c
+	}
+	if node.Operator() == Not {
+		child := Flatten(node.Children()[0])
+		if inner, ok := child.(Node); ok && inner.Operator() == Not && inner.Children()[0] != nil {
+			return inner.Children()[0] // NOT NOT x = x
+		}
+		return NewNegation(child)
+	}
+	conditions := make([]Condition, 0, len(node.Children()))
+	for _, child := range node.Children() {
+		if child == nil {
+			continue // skip null conditions
+		}
+		child = Flatten(child)
+		if inner, ok := child.(Node); ok && inner.Operator() == node.Operator() && len(inner.Children()) > 0 {
+			conditions = append(conditions, inner.Children()...)
+		} else {
+			conditions = append(conditions, child)
+		}
+	}
+	return NewMulti(node.Operator(), conditions...)
+}
+
+// Pretty-print condition tree, one condition per line,
+// Values are rendered in the current dialect
+func Format(c Condition) string {
+	lines := make([]string, 0)
+	Walk(c, func(c Condition, depth int) bool {
+		indent := strings.Repeat("  ", depth)
+		if c == nil {
+			lines = append(lines, indent+"<nil>")
+		} else if node, ok := c.(Node); ok {
+			lines = append(lines, indent+node.Operator())
+		} else {
+			condition, values := c.Build()
+			lines = append(lines, indent+dialect.Current().Render(condition, values))
+		}
+		return true
+	})
+	return strings.Join(lines, "\n")
+}
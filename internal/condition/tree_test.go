@@ -0,0 +1,87 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/roidaradal/rdb/internal/dialect"
+	"github.com/roidaradal/rdb/internal/rdb"
+)
+
+type flattenItem struct {
+	X int
+	Y int
+}
+
+func TestFlattenKeepsMeaning(t *testing.T) {
+	rdb.Initialize()
+	item := &flattenItem{}
+	if err := rdb.AddType(item); err != nil {
+		t.Fatal(err)
+	}
+	x := NewValue(&item.X, 1, Equal)
+	y := NewValue(&item.Y, 2, Equal)
+	testCases := []Condition{
+		NewMulti(And, y, NewMulti(And)),
+		NewMulti(And, x, NewMulti(And, nil)),
+		NewMulti(Or, y, NewMulti(Or)),
+		NewMulti(Or, x, NewMulti(Or, nil, y)),
+		NewNegation(nil),
+		NewNegation(NewNegation(nil)),
+		NewMulti(And, x, NewNegation(NewNegation(nil))),
+	}
+	for _, c := range testCases {
+		wantQuery, wantValues := c.Build()
+		gotQuery, gotValues := Flatten(c).Build()
+		if gotQuery != wantQuery || len(gotValues) != len(wantValues) {
+			t.Errorf("Flatten changed %q %v into %q %v", wantQuery, wantValues, gotQuery, gotValues)
+		}
+	}
+}
+
+func TestFlattenMerges(t *testing.T) {
+	rdb.Initialize()
+	item := &flattenItem{}
+	if err := rdb.AddType(item); err != nil {
+		t.Fatal(err)
+	}
+	x := NewValue(&item.X, 1, Equal)
+	y := NewValue(&item.Y, 2, Equal)
+	testCases := []struct {
+		condition Condition
+		want      string
+	}{
+		{NewMulti(And, x, NewMulti(And, y, NewMulti(And, x))), "(`X` = ? AND `Y` = ? AND `X` = ?)"},
+		{NewMulti(Or, x, nil, NewMulti(Or, y)), "(`X` = ? OR `Y` = ?)"},
+		{NewNegation(NewNegation(x)), "`X` = ?"},
+	}
+	for _, tc := range testCases {
+		got, _ := Flatten(tc.condition).Build()
+		if got != tc.want {
+			t.Errorf("Flatten: got %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestPostgreSQLConditions(t *testing.T) {
+	rdb.Initialize()
+	item := &flattenItem{}
+	if err := rdb.AddType(item); err != nil {
+		t.Fatal(err)
+	}
+	dialect.Set(dialect.PostgreSQL)
+	defer dialect.Set(dialect.MySQL)
+	testCases := []struct {
+		condition Condition
+		want      string
+	}{
+		{NewFieldValue("flattenItem", "X", "a%", ILike), `"X" ILIKE $1`},
+		{NewFieldValue("flattenItem", "X", "^a", Regexp), `"X" ~ $1`},
+		{NewFieldValue("flattenItem", "X", "^a", NotRegexp), `"X" !~ $1`},
+	}
+	for _, tc := range testCases {
+		query, _ := tc.condition.Build()
+		if got := dialect.PostgreSQL.Rebind(query); got != tc.want {
+			t.Errorf("got %s, want %s", got, tc.want)
+		}
+	}
+}
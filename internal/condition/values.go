@@ -1,10 +1,13 @@
 package condition
 
 import (
+	"database/sql/driver"
 	"fmt"
+	"strings"
 
-	"github.com/roidaradal/fn/dyn"
+	"github.com/roidaradal/fn/lang"
 	"github.com/roidaradal/fn/str"
+	"github.com/roidaradal/rdb/internal/dialect"
 )
 
 const (
@@ -18,11 +21,27 @@ const (
 	NotIn        string = "NOT IN"
 	And          string = "AND"
 	Or           string = "OR"
+	Not          string = "NOT"
+	Exists       string = "EXISTS"
+	NotExists    string = "NOT EXISTS"
 	Prefix       string = "PREFIX"
 	Suffix       string = "SUFFIX"
 	Substring    string = "SUBSTRING"
+	Between      string = "BETWEEN"
+	NotBetween   string = "NOT BETWEEN"
+	IsNull       string = "IS NULL"
+	IsNotNull    string = "IS NOT NULL"
+	Like         string = "LIKE"
+	NotLike      string = "NOT LIKE"
+	ILike        string = "ILIKE"
+	EqualFold    string = "EQUALFOLD"
+	Regexp       string = "REGEXP"
+	NotRegexp    string = "NOT REGEXP"
 )
 
+// Escapes LIKE wildcards (%, _) and the escape character (\) in values
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 const (
 	falseCondition string = "false"
 	trueCondition  string = "true"
@@ -42,25 +61,79 @@ func trueConditionValues() (string, []any) {
 // Values list corresponds to ? in the query,
 // Used for solo value conditions
 func soloConditionValues(column, operator string, value any) (string, []any) {
-	isValueNil := dyn.IsNull(value)
-	if operator == Equal && isValueNil {
+	isValueNil := isNullValue(value)
+	switch {
+	case operator == IsNull, operator == Equal && isValueNil:
 		return fmt.Sprintf("%s IS NULL", column), []any{}
-	} else if operator == NotEqual && isValueNil {
+	case operator == IsNotNull, operator == NotEqual && isValueNil:
 		return fmt.Sprintf("%s IS NOT NULL", column), []any{}
-	} else if operator == Prefix {
-		prefix := fmt.Sprintf("%v%%", value)
-		return fmt.Sprintf("%s LIKE ?", column), []any{prefix}
-	} else if operator == Suffix {
-		suffix := fmt.Sprintf("%%%v", value)
-		return fmt.Sprintf("%s LIKE ?", column), []any{suffix}
-	} else if operator == Substring {
-		substring := fmt.Sprintf("%%%v%%", value)
-		return fmt.Sprintf("%s LIKE ?", column), []any{substring}
-	} else {
+	case operator == Prefix:
+		prefix := fmt.Sprintf("%s%%", escapeLike(value))
+		return escapedLikeCondition(column), []any{prefix}
+	case operator == Suffix:
+		suffix := fmt.Sprintf("%%%s", escapeLike(value))
+		return escapedLikeCondition(column), []any{suffix}
+	case operator == Substring:
+		substring := fmt.Sprintf("%%%s%%", escapeLike(value))
+		return escapedLikeCondition(column), []any{substring}
+	case operator == ILike:
+		return caseInsensitiveCondition(column, Like), []any{value}
+	case operator == EqualFold:
+		return fmt.Sprintf("LOWER(%s) = LOWER(?)", column), []any{value}
+	case operator == Regexp, operator == NotRegexp:
+		return regexpCondition(column, operator), []any{value}
+	default:
 		return fmt.Sprintf("%s %s ?", column, operator), []any{value}
 	}
 }
 
+// Build condition string for range conditions (BETWEEN, NOT BETWEEN)
+func rangeCondition(column, operator string) string {
+	return fmt.Sprintf("%s %s ? AND ?", column, operator)
+}
+
+// Escape LIKE wildcards in value, so it is matched literally
+func escapeLike(value any) string {
+	return likeEscaper.Replace(fmt.Sprintf("%v", value))
+}
+
+// Build LIKE condition for values with escaped wildcards;
+// SQLite has no default escape character
+func escapedLikeCondition(column string) string {
+	if dialect.Current() == dialect.SQLite {
+		return fmt.Sprintf(`%s LIKE ? ESCAPE '\'`, column)
+	}
+	return fmt.Sprintf("%s LIKE ?", column)
+}
+
+// Build case-insensitive LIKE condition, using ILIKE on PostgreSQL
+func caseInsensitiveCondition(column, operator string) string {
+	if dialect.Current() == dialect.PostgreSQL {
+		return fmt.Sprintf("%s ILIKE ?", column)
+	}
+	return fmt.Sprintf("LOWER(%s) %s LOWER(?)", column, operator)
+}
+
+// Build regular expression condition, using ~ and !~ on PostgreSQL
+func regexpCondition(column, operator string) string {
+	if dialect.Current() == dialect.PostgreSQL {
+		operator = lang.Ternary(operator == Regexp, "~", "!~")
+	}
+	return fmt.Sprintf("%s %s ?", column, operator)
+}
+
+// Check if value is NULL: nil, nil pointer, or driver.Valuer with NULL value (e.g. sql.Null[T])
+func isNullValue(value any) bool {
+	if dialect.IsNull(value) {
+		return true
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		return err == nil && v == nil
+	}
+	return false
+}
+
 // Build condition string for list value conditions,
 // Adds repeated placeholder ? to end of condition
 func listCondition(column, operator string, numValues int) string {
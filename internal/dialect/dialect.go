@@ -0,0 +1,39 @@
+// Package dialect contains the SQL dialect settings and SQL value rendering
+package dialect
+
+import "sync/atomic"
+
+// SQL dialect
+type Dialect int
+
+const (
+	MySQL Dialect = iota
+	PostgreSQL
+	SQLite
+)
+
+var current atomic.Int32 // default: MySQL
+
+// Set the current SQL dialect
+func Set(d Dialect) {
+	current.Store(int32(d))
+}
+
+// Get the current SQL dialect
+func Current() Dialect {
+	return Dialect(current.Load())
+}
+
+// Return dialect name
+func (d Dialect) String() string {
+	switch d {
+	case MySQL:
+		return "MySQL"
+	case PostgreSQL:
+		return "PostgreSQL"
+	case SQLite:
+		return "SQLite"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,235 @@
+package dialect
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roidaradal/fn/lang"
+)
+
+const timeFormat string = "2006-01-02 15:04:05.999999"
+
+// Render query by replacing placeholders with quoted and escaped values.
+// Only ? placeholders outside of quoted strings, identifiers, and comments are replaced;
+// extra placeholders (not enough values) are left as-is
+func (d Dialect) Render(query string, values []any) string {
+	idx := 0
+	return d.rewrite(query, func() string {
+		if idx >= len(values) {
+			return "?"
+		}
+		literal := d.Literal(values[idx])
+		idx += 1
+		return literal
+	})
+}
+
+// Convert query built with `identifiers` and ? placeholders into the dialect's syntax:
+// PostgreSQL uses "identifiers" and $1, $2, ... placeholders; MySQL and SQLite are unchanged
+func (d Dialect) Rebind(query string) string {
+	if d != PostgreSQL {
+		return query
+	}
+	idx := 0
+	return d.rewrite(query, func() string {
+		idx += 1
+		return fmt.Sprintf("$%d", idx)
+	})
+}
+
+// Rewrite query, replacing each ? placeholder outside of quoted strings, identifiers,
+// and comments with the placeholder function's output; on PostgreSQL, `identifiers` are double-quoted
+func (d Dialect) rewrite(query string, placeholder func() string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		char := query[i]
+		switch {
+		case char == '`' && d == PostgreSQL:
+			end := d.quotedEnd(query, i)
+			b.WriteString(doubleQuote(query[i:end]))
+			i = end - 1
+		case char == '\'' || char == '"' || char == '`':
+			end := d.quotedEnd(query, i)
+			b.WriteString(query[i:end])
+			i = end - 1
+		case char == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			end = lineEnd(query, i, end)
+			b.WriteString(query[i:end])
+			i = end - 1
+		case char == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			end = commentEnd(query, i, end)
+			b.WriteString(query[i:end])
+			i = end - 1
+		case char == '?':
+			b.WriteString(placeholder())
+		default:
+			b.WriteByte(char)
+		}
+	}
+	return b.String()
+}
+
+// Check if value is nil or a nil pointer, map, slice, channel, function or interface;
+// unlike dyn.IsNull, arrays are never nil
+func IsNull(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// Render value as SQL literal
+func (d Dialect) Literal(value any) string {
+	if IsNull(value) {
+		return "NULL"
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		driverValue, err := valuer.Value()
+		if err != nil {
+			return d.quoteString(fmt.Sprintf("%v", value))
+		}
+		if _, ok := driverValue.(driver.Valuer); ok {
+			// avoid recursion on self-returning Valuers
+			return d.quoteString(fmt.Sprintf("%v", driverValue))
+		}
+		return d.Literal(driverValue)
+	}
+	switch v := value.(type) {
+	case string:
+		return d.quoteString(v)
+	case []byte:
+		return d.quoteBytes(v)
+	case bool:
+		return d.boolean(v)
+	case time.Time:
+		return d.quoteString(v.Format(timeFormat))
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		return d.Literal(rv.Elem().Interface())
+	case reflect.String:
+		return d.quoteString(rv.String())
+	case reflect.Bool:
+		return d.boolean(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return d.quoteString(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return d.quoteBytes(rv.Bytes())
+		}
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(data), rv)
+			return d.quoteBytes(data)
+		}
+	}
+	return d.quoteString(fmt.Sprintf("%v", value))
+}
+
+// Quote and escape string literal
+func (d Dialect) quoteString(text string) string {
+	var b strings.Builder
+	b.Grow(len(text) + 2)
+	b.WriteByte('\'')
+	for _, char := range text {
+		switch {
+		case char == '\'':
+			b.WriteString("''")
+		case d == MySQL && char == '\\':
+			b.WriteString(`\\`)
+		case d == MySQL && char == 0:
+			b.WriteString(`\0`)
+		case d == MySQL && char == '\n':
+			b.WriteString(`\n`)
+		case d == MySQL && char == '\r':
+			b.WriteString(`\r`)
+		case d == MySQL && char == 0x1a:
+			b.WriteString(`\Z`)
+		default:
+			b.WriteRune(char)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// Render byte slice as hex literal
+func (d Dialect) quoteBytes(data []byte) string {
+	if d == PostgreSQL {
+		return fmt.Sprintf(`'\x%s'::bytea`, hex.EncodeToString(data))
+	}
+	return fmt.Sprintf("X'%s'", hex.EncodeToString(data))
+}
+
+// Render boolean literal
+func (d Dialect) boolean(flag bool) string {
+	if d == SQLite {
+		return lang.Ternary(flag, "1", "0")
+	}
+	return lang.Ternary(flag, "TRUE", "FALSE")
+}
+
+// Find end index (exclusive) of quoted string or identifier starting at index start
+func (d Dialect) quotedEnd(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		char := query[i]
+		if char == '\\' && d == MySQL && quote != '`' {
+			i += 1 // skip escaped character
+		} else if char == quote {
+			if i+1 < len(query) && query[i+1] == quote {
+				i += 1 // doubled quote
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// Convert `identifier` into "identifier"
+func doubleQuote(identifier string) string {
+	name := strings.TrimSuffix(strings.TrimPrefix(identifier, "`"), "`")
+	name = strings.ReplaceAll(name, "``", "`")
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
+// Get end index (exclusive) of line comment
+func lineEnd(query string, start, offset int) int {
+	if offset < 0 {
+		return len(query)
+	}
+	return start + offset
+}
+
+// Get end index (exclusive) of block comment
+func commentEnd(query string, start, offset int) int {
+	if offset < 0 {
+		return len(query)
+	}
+	return start + 2 + offset + 2
+}
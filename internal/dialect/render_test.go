@@ -0,0 +1,28 @@
+package dialect
+
+import "testing"
+
+func TestRebind(t *testing.T) {
+	query := "SELECT `a`, `b``c` FROM `t` WHERE `a` = ? AND `d` = '?' AND \"e\" > ? -- ?\n"
+	testCases := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{MySQL, query},
+		{SQLite, query},
+		{PostgreSQL, "SELECT \"a\", \"b`c\" FROM \"t\" WHERE \"a\" = $1 AND \"d\" = '?' AND \"e\" > $2 -- ?\n"},
+	}
+	for _, tc := range testCases {
+		if got := tc.dialect.Rebind(query); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.dialect, got, tc.want)
+		}
+	}
+}
+
+func TestRenderPostgreSQL(t *testing.T) {
+	got := PostgreSQL.Render("UPDATE `t` SET `data` = ?, `ok` = ? WHERE `id` = ?", []any{[]byte{0xab}, true})
+	want := `UPDATE "t" SET "data" = '\xab'::bytea, "ok" = TRUE WHERE "id" = ?`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,257 @@
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/roidaradal/fn/dict"
+	"github.com/roidaradal/fn/lang"
+)
+
+// Default histogram buckets, in seconds
+var DefaultBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Expvar name of the current Expvar Recorder's metrics
+const ExpvarName string = "rdb_metrics"
+
+// Expvar Recorder: counters and histograms are expvar Maps owned by the recorder,
+// keyed by the rendered label set; the current recorder's metrics are published as ExpvarName
+type Expvar struct {
+	mu         sync.Mutex
+	buckets    []float64
+	counters   map[string]*expvar.Map // {MetricName => {Labels => *expvar.Float}}
+	histograms map[string]*expvar.Map // {MetricName => {Labels => *histogram}}
+}
+
+// Histogram value for one label set
+type histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64 // non-cumulative count per bucket
+	count   uint64
+	sum     float64
+}
+
+// Create new Expvar Recorder with default buckets
+func NewExpvar() *Expvar {
+	return NewExpvarBuckets(DefaultBuckets)
+}
+
+// Create new Expvar Recorder with given histogram buckets
+func NewExpvarBuckets(buckets []float64) *Expvar {
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &Expvar{
+		buckets:    buckets,
+		counters:   make(map[string]*expvar.Map),
+		histograms: make(map[string]*expvar.Map),
+	}
+}
+
+// Add delta to counter
+func (e *Expvar) Count(name string, labels Labels, delta float64) {
+	e.mu.Lock()
+	m := getMap(e.counters, name)
+	e.mu.Unlock()
+	m.AddFloat(labels.String(), delta)
+}
+
+// Add observation to histogram
+func (e *Expvar) Observe(name string, labels Labels, value float64) {
+	key := labels.String()
+	e.mu.Lock()
+	m := getMap(e.histograms, name)
+	h, ok := m.Get(key).(*histogram)
+	if !ok {
+		h = newHistogram(e.buckets)
+		m.Set(key, h)
+	}
+	e.mu.Unlock()
+	h.observe(value)
+}
+
+// Write metrics in Prometheus text exposition format
+func (e *Expvar) WritePrometheus(w io.Writer) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var b strings.Builder
+	for _, name := range sortedKeys(e.counters) {
+		fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+		e.counters[name].Do(func(kv expvar.KeyValue) {
+			if value, ok := kv.Value.(*expvar.Float); ok {
+				fmt.Fprintf(&b, "%s%s %s\n", name, wrapLabels(kv.Key), formatFloat(value.Value()))
+			}
+		})
+	}
+	for _, name := range sortedKeys(e.histograms) {
+		fmt.Fprintf(&b, "# TYPE %s histogram\n", name)
+		e.histograms[name].Do(func(kv expvar.KeyValue) {
+			if h, ok := kv.Value.(*histogram); ok {
+				h.write(&b, name, kv.Key)
+			}
+		})
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Get counters and histograms as JSON, by metric name
+func (e *Expvar) snapshot() map[string]json.RawMessage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	output := make(map[string]json.RawMessage, len(e.counters)+len(e.histograms))
+	for _, maps := range []map[string]*expvar.Map{e.counters, e.histograms} {
+		for name, m := range maps {
+			output[name] = json.RawMessage(m.String())
+		}
+	}
+	return output
+}
+
+// Publish the current Expvar Recorder's metrics as ExpvarName, unless the name is already taken
+func init() {
+	if expvar.Get(ExpvarName) != nil {
+		return
+	}
+	expvar.Publish(ExpvarName, expvar.Func(func() any {
+		e, ok := CurrentRecorder().(*Expvar)
+		if !ok {
+			return nil
+		}
+		return e.snapshot()
+	}))
+}
+
+// Serve metrics in Prometheus text exposition format
+func (e *Expvar) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	err := e.WritePrometheus(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Handler that serves the current recorder's metrics in Prometheus text format,
+// if the recorder supports it
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := CurrentRecorder().(http.Handler)
+		if !ok {
+			http.Error(w, "metrics recorder has no exposition handler", http.StatusNotFound)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Render labels as Prometheus label pairs (without braces)
+func (l Labels) String() string {
+	pairs := make([]string, 0, 4)
+	for _, pair := range [][2]string{
+		{"schema", l.Schema},
+		{"table", l.Table},
+		{"operation", l.Operation},
+		{"outcome", l.Outcome},
+	} {
+		if pair[1] == "" {
+			continue // skip blank labels
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%q", pair[0], pair[1]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Get recorder's expvar Map of given metric name, create if not yet existing;
+// maps are not published individually, so recorders never share them
+func getMap(maps map[string]*expvar.Map, name string) *expvar.Map {
+	if m, ok := maps[name]; ok {
+		return m
+	}
+	m := new(expvar.Map).Init()
+	maps[name] = m
+	return m
+}
+
+// Create new histogram with given buckets
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{
+		buckets: buckets,
+		counts:  make([]uint64, len(buckets)+1), // last = +Inf
+	}
+}
+
+// Add observation to histogram
+func (h *histogram) observe(value float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	idx, _ := slices.BinarySearch(h.buckets, value)
+	h.counts[idx] += 1
+	h.count += 1
+	h.sum += value
+}
+
+// Write histogram lines in Prometheus text format
+func (h *histogram) write(b *strings.Builder, name, labels string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sep := lang.Ternary(labels != "", ",", "")
+	var cumulative uint64 = 0
+	for i, count := range h.counts {
+		cumulative += count
+		bound := "+Inf"
+		if i < len(h.buckets) {
+			bound = formatFloat(h.buckets[i])
+		}
+		fmt.Fprintf(b, "%s_bucket{%s%sle=%q} %d\n", name, labels, sep, bound, cumulative)
+	}
+	fmt.Fprintf(b, "%s_sum%s %s\n", name, wrapLabels(labels), formatFloat(h.sum))
+	fmt.Fprintf(b, "%s_count%s %d\n", name, wrapLabels(labels), h.count)
+}
+
+// Implement expvar.Var: output histogram as JSON
+func (h *histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	buckets := make(map[string]uint64, len(h.counts))
+	for i, count := range h.counts {
+		bound := "+Inf"
+		if i < len(h.buckets) {
+			bound = formatFloat(h.buckets[i])
+		}
+		buckets[bound] = count
+	}
+	output, _ := json.Marshal(map[string]any{
+		"count":   h.count,
+		"sum":     h.sum,
+		"buckets": buckets,
+	})
+	return string(output)
+}
+
+// Wrap non-blank labels in braces
+func wrapLabels(labels string) string {
+	return lang.Ternary(labels != "", "{"+labels+"}", "")
+}
+
+// Format float for Prometheus output
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// Get sorted keys of map
+func sortedKeys[V any](items map[string]V) []string {
+	keys := dict.Keys(items)
+	slices.Sort(keys)
+	return keys
+}
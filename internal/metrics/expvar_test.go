@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"expvar"
+	"strings"
+	"testing"
+)
+
+func TestExpvarRecordersAreSeparate(t *testing.T) {
+	// Foreign var with a metric name must not be reused or cause panics
+	expvar.Publish(QueriesTotal, new(expvar.Int))
+
+	labels := Labels{Table: "t", Operation: "op"}
+	first := NewExpvarBuckets([]float64{1})
+	second := NewExpvarBuckets([]float64{0.5, 2})
+	first.Count(QueriesTotal, labels, 1)
+	first.Observe(QueryDuration, labels, 0.7)
+	second.Observe(QueryDuration, labels, 0.7)
+
+	var b strings.Builder
+	if err := first.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	output := b.String()
+	for _, line := range []string{
+		`rdb_queries_total{table="t",operation="op"} 1`,
+		`rdb_query_duration_seconds_bucket{table="t",operation="op",le="1"} 1`,
+	} {
+		if !strings.Contains(output, line) {
+			t.Errorf("first recorder: missing %s in\n%s", line, output)
+		}
+	}
+
+	b.Reset()
+	if err := second.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	output = b.String()
+	if strings.Contains(output, QueriesTotal) || strings.Contains(output, `le="1"`) {
+		t.Errorf("second recorder: has first recorder's metrics\n%s", output)
+	}
+	if !strings.Contains(output, `rdb_query_duration_seconds_bucket{table="t",operation="op",le="2"} 1`) {
+		t.Errorf("second recorder: missing own buckets\n%s", output)
+	}
+}
+
+func TestExpvarPublishesCurrentRecorder(t *testing.T) {
+	previous := CurrentRecorder()
+	defer SetRecorder(previous)
+
+	r := NewExpvar()
+	SetRecorder(r)
+	r.Count(TransactionsTotal, Labels{Outcome: OutcomeCommit}, 2)
+	published := expvar.Get(ExpvarName)
+	if published == nil {
+		t.Fatalf("%s is not published", ExpvarName)
+	}
+	if output := published.String(); !strings.Contains(output, TransactionsTotal) {
+		t.Errorf("published metrics missing %s: %s", TransactionsTotal, output)
+	}
+
+	SetRecorder(nil)
+	if output := published.String(); output != "null" {
+		t.Errorf("published metrics without Expvar recorder: got %s, want null", output)
+	}
+}
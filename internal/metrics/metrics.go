@@ -0,0 +1,106 @@
+// Package metrics contains the query metrics recorder and its default expvar implementation
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	QueriesTotal          string = "rdb_queries_total"                     // counter: executed queries
+	QueryErrorsTotal      string = "rdb_query_errors_total"                // counter: failed queries
+	QueryDuration         string = "rdb_query_duration_seconds"            // histogram: query latency
+	RowsAffectedTotal     string = "rdb_rows_affected_total"               // counter: rows affected by writes
+	TransactionsTotal     string = "rdb_transactions_total"                // counter: transaction outcomes
+	SchemaOperationsTotal string = "rdb_schema_operations_total"           // counter: Schema operations
+	SchemaDuration        string = "rdb_schema_operation_duration_seconds" // histogram: Schema operation latency
+)
+
+const (
+	OutcomeOK       string = "ok"       // query succeeded
+	OutcomeError    string = "error"    // query failed
+	OutcomeCommit   string = "commit"   // transaction committed
+	OutcomeRollback string = "rollback" // transaction rolled back
+	OutcomeFailed   string = "failed"   // commit or rollback failed
+)
+
+// Metric labels; blank labels are omitted
+type Labels struct {
+	Schema    string
+	Table     string
+	Operation string
+	Outcome   string
+}
+
+// Recorder collects counters and histograms
+type Recorder interface {
+	Count(name string, labels Labels, delta float64)   // Add delta to counter
+	Observe(name string, labels Labels, value float64) // Add observation to histogram
+}
+
+// Recorder that does nothing
+type nopRecorder struct{}
+
+func (nopRecorder) Count(string, Labels, float64)   {}
+func (nopRecorder) Observe(string, Labels, float64) {}
+
+var (
+	mu       sync.RWMutex
+	recorder Recorder = NewExpvar()
+)
+
+// Set the metrics recorder; nil disables metrics
+func SetRecorder(r Recorder) {
+	mu.Lock()
+	defer mu.Unlock()
+	if r == nil {
+		r = nopRecorder{}
+	}
+	recorder = r
+}
+
+// Get the current metrics recorder
+func CurrentRecorder() Recorder {
+	mu.RLock()
+	defer mu.RUnlock()
+	return recorder
+}
+
+// Record query count, latency, errors and rows affected
+func ObserveQuery(labels Labels, start time.Time, rowsAffected int, err error) {
+	r := CurrentRecorder()
+	labels.Outcome = outcomeOf(err)
+	r.Count(QueriesTotal, labels, 1)
+	if labels.Outcome == OutcomeError {
+		r.Count(QueryErrorsTotal, labels, 1)
+	}
+	if rowsAffected > 0 {
+		r.Count(RowsAffectedTotal, labels, float64(rowsAffected))
+	}
+	labels.Outcome = ""
+	r.Observe(QueryDuration, labels, time.Since(start).Seconds())
+}
+
+// Record Schema operation count and latency
+func ObserveSchema(labels Labels, start time.Time, err error) {
+	r := CurrentRecorder()
+	labels.Outcome = outcomeOf(err)
+	r.Count(SchemaOperationsTotal, labels, 1)
+	labels.Outcome = ""
+	r.Observe(SchemaDuration, labels, time.Since(start).Seconds())
+}
+
+// Record transaction outcome
+func ObserveTransaction(outcome string) {
+	CurrentRecorder().Count(TransactionsTotal, Labels{Outcome: outcome}, 1)
+}
+
+// Get outcome label of error; no rows found is not counted as error
+func outcomeOf(err error) string {
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		return OutcomeOK
+	}
+	return OutcomeError
+}
@@ -0,0 +1,235 @@
+package query
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/roidaradal/fn/list"
+	"github.com/roidaradal/rdb/internal/condition"
+	"github.com/roidaradal/rdb/internal/rdb"
+)
+
+// Aggregate Query, where T = source row type, R = result type;
+// group keys and aggregates are read into R's fields
+type Aggregate[T, R any] struct {
+	conditionQuery
+	groups  []string            // group by columns
+	columns []aggregateColumn   // selected group keys and aggregates
+	having  condition.Condition // condition on result fields
+	order   string
+	limit   uint
+	invalid []error // problems found when adding columns
+}
+
+// Selected expression and its alias (result column)
+type aggregateColumn struct {
+	expression string
+	alias      string
+}
+
+// Create new Aggregate Query
+func NewAggregate[T, R any](table string) *Aggregate[T, R] {
+	q := &Aggregate[T, R]{}
+	q.initializeOptional(table)
+	q.groups = make([]string, 0)
+	q.columns = make([]aggregateColumn, 0)
+	q.invalid = make([]error, 0)
+	return q
+}
+
+// Add group key: source field is read into result field
+func (q *Aggregate[T, R]) GroupBy(fieldRef, resultFieldRef any) {
+	column := rdb.GetColumnName(fieldRef)
+	if column == "" {
+		q.addProblem("group", ErrUnknownField)
+	}
+	q.groups = append(q.groups, column)
+	q.addColumn("group", column, resultFieldRef)
+}
+
+// Add COUNT(*) into result field
+func (q *Aggregate[T, R]) Count(resultFieldRef any) {
+	q.addColumn("count", "COUNT(*)", resultFieldRef)
+}
+
+// Add COUNT(DISTINCT field) into result field
+func (q *Aggregate[T, R]) CountDistinct(fieldRef, resultFieldRef any) {
+	q.addAggregate("COUNT(DISTINCT %s)", "count distinct", fieldRef, resultFieldRef)
+}
+
+// Add SUM(field) into result field
+func (q *Aggregate[T, R]) Sum(fieldRef, resultFieldRef any) {
+	q.addAggregate("SUM(%s)", "sum", fieldRef, resultFieldRef)
+}
+
+// Add AVG(field) into result field
+func (q *Aggregate[T, R]) Avg(fieldRef, resultFieldRef any) {
+	q.addAggregate("AVG(%s)", "avg", fieldRef, resultFieldRef)
+}
+
+// Add MIN(field) into result field
+func (q *Aggregate[T, R]) Min(fieldRef, resultFieldRef any) {
+	q.addAggregate("MIN(%s)", "min", fieldRef, resultFieldRef)
+}
+
+// Add MAX(field) into result field
+func (q *Aggregate[T, R]) Max(fieldRef, resultFieldRef any) {
+	q.addAggregate("MAX(%s)", "max", fieldRef, resultFieldRef)
+}
+
+// Set HAVING condition, using result field references (e.g. rdb.Greater(&result.Total, 100))
+func (q *Aggregate[T, R]) Having(havingCondition condition.Condition) {
+	q.having = havingCondition
+}
+
+// Set order by result field (ascending)
+func (q *Aggregate[T, R]) OrderAsc(resultFieldRef any) {
+	q.order = ""
+	q.ThenAsc(resultFieldRef)
+}
+
+// Set order by result field (descending)
+func (q *Aggregate[T, R]) OrderDesc(resultFieldRef any) {
+	q.order = ""
+	q.ThenDesc(resultFieldRef)
+}
+
+// Add order by result field (ascending), after existing order
+func (q *Aggregate[T, R]) ThenAsc(resultFieldRef any) {
+	q.addOrder(resultFieldRef, "ASC")
+}
+
+// Add order by result field (descending), after existing order
+func (q *Aggregate[T, R]) ThenDesc(resultFieldRef any) {
+	q.addOrder(resultFieldRef, "DESC")
+}
+
+// Set Aggregate limit
+func (q *Aggregate[T, R]) Limit(limit uint) {
+	q.limit = limit
+}
+
+// Add aggregate expression over source field
+func (q *Aggregate[T, R]) addAggregate(format, label string, fieldRef, resultFieldRef any) {
+	column := rdb.GetColumnName(fieldRef)
+	if column == "" {
+		q.addProblem(label, ErrUnknownField)
+	}
+	q.addColumn(label, fmt.Sprintf(format, column), resultFieldRef)
+}
+
+// Add selected expression, aliased as result field's column
+func (q *Aggregate[T, R]) addColumn(label, expression string, resultFieldRef any) {
+	alias := rdb.GetColumnName(resultFieldRef)
+	if alias == "" {
+		q.addProblem(label+" result", ErrUnknownField)
+	}
+	q.columns = append(q.columns, aggregateColumn{expression, alias})
+}
+
+// Append result field to order
+func (q *Aggregate[T, R]) addOrder(resultFieldRef any, direction string) {
+	alias := rdb.GetColumnName(resultFieldRef)
+	if alias == "" {
+		q.addProblem("order", ErrUnknownField)
+		return
+	}
+	order := fmt.Sprintf("%s %s", alias, direction)
+	if q.order == "" {
+		q.order = order
+	} else {
+		q.order = fmt.Sprintf("%s, %s", q.order, order)
+	}
+}
+
+// Record problem found when adding columns
+func (q *Aggregate[T, R]) addProblem(label string, err error) {
+	q.invalid = append(q.invalid, fmt.Errorf("%s #%d: %w", label, len(q.columns)+1, err))
+}
+
+// Get result columns, used by the row reader
+func (q Aggregate[T, R]) aliases() []string {
+	return list.Map(q.columns, func(c aggregateColumn) string {
+		return c.alias
+	})
+}
+
+// Build Aggregate Query
+func (q Aggregate[T, R]) Build() (string, []any) {
+	condition, values, err := q.conditionQuery.preBuildCheck()
+	if err != nil || len(q.columns) == 0 || len(q.invalid) > 0 {
+		return emptyQueryValues()
+	}
+	selected := list.Map(q.columns, func(c aggregateColumn) string {
+		if c.expression == c.alias {
+			return c.alias
+		}
+		return fmt.Sprintf("%s AS %s", c.expression, c.alias)
+	})
+	query := "SELECT %s FROM %s WHERE %s"
+	query = fmt.Sprintf(query, strings.Join(selected, ", "), q.table, condition)
+	if len(q.groups) > 0 {
+		query = fmt.Sprintf("%s GROUP BY %s", query, strings.Join(q.groups, ", "))
+	}
+	if q.having != nil {
+		having, havingValues := q.having.Build()
+		// Replace result columns with their expressions, as not all dialects allow aliases in HAVING
+		pairs := make([]string, 0, 2*len(q.columns))
+		for _, c := range q.columns {
+			pairs = append(pairs, c.alias, c.expression)
+		}
+		having = strings.NewReplacer(pairs...).Replace(having)
+		query = fmt.Sprintf("%s HAVING %s", query, having)
+		values = append(values, havingValues...)
+	}
+	if q.order != "" {
+		query = fmt.Sprintf("%s ORDER BY %s", query, q.order)
+	}
+	if q.limit > 0 {
+		query = fmt.Sprintf("%s LIMIT %d", query, q.limit)
+	}
+	return query, values
+}
+
+// Validate Aggregate Query
+func (q Aggregate[T, R]) Validate() error {
+	problems := q.conditionQuery.problems()
+	if len(q.columns) == 0 {
+		problems = append(problems, ErrMissingColumns)
+	}
+	problems = append(problems, q.invalid...)
+	return newValidationError(problems)
+}
+
+// Execute Aggregate Query and get list of results
+func (q Aggregate[T, R]) Query(dbc *sql.DB) ([]*R, error) {
+	reader := rdb.NewReader[R](q.aliases()...)
+	query, values, err := preReadCheck(q, dbc, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*R, 0)
+	err = readRows(q, dbc, query, values, reader, func(result *R) {
+		results = append(results, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Execute Aggregate Query and get map of results, keyed by given key function (e.g. composite group key)
+func AggregateMap[K comparable, T, R any](q *Aggregate[T, R], dbc *sql.DB, key func(*R) K) (map[K]*R, error) {
+	// Note: Cannot be method as generics are not supported in methods
+	results, err := q.Query(dbc)
+	if err != nil {
+		return nil, err
+	}
+	resultMap := make(map[K]*R, len(results))
+	for _, result := range results {
+		resultMap[key(result)] = result
+	}
+	return resultMap, nil
+}
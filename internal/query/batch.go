@@ -0,0 +1,290 @@
+package query
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/roidaradal/fn/dyn"
+	"github.com/roidaradal/fn/list"
+	"github.com/roidaradal/rdb/internal/dialect"
+)
+
+// Default InsertRows batch limits
+const (
+	DefaultMaxParams int = 65535   // MySQL placeholder limit
+	DefaultMaxBytes  int = 4 << 20 // 4MB, MySQL max_allowed_packet (older servers)
+)
+
+// Query that can be split into batches
+type BatchQuery interface {
+	Query
+	Validate() error
+	BatchQueries() []Query
+}
+
+// Batches computed by Batches(), reused until the query's rows or limits change
+type batchCache[Q any] struct {
+	batches []*Q
+}
+
+// Clear cached batches
+func (c *batchCache[Q]) reset() {
+	if c != nil {
+		c.batches = nil
+	}
+}
+
+// Get cached batches, nil if not computed yet
+func (c *batchCache[Q]) get() []*Q {
+	if c == nil {
+		return nil
+	}
+	return c.batches
+}
+
+// Cache computed batches
+func (c *batchCache[Q]) set(batches []*Q) []*Q {
+	if c != nil {
+		c.batches = batches
+	}
+	return batches
+}
+
+// Result of batched execution
+type BatchResult struct {
+	Results      []*sql.Result // per-batch results, in order
+	RowsAffected int           // total rows affected
+}
+
+// Split InsertRows Query into batches that are within the parameter and byte limits;
+// invalid queries are returned as is. Batches are computed once, until rows or limits change
+func (q InsertRows) Batches() []*InsertRows {
+	if batches := q.cache.get(); batches != nil {
+		return batches
+	}
+	if q.Validate() != nil {
+		return []*InsertRows{&q}
+	}
+	numColumns := len(q.rows[0])
+	maxParams := max(q.maxParams, numColumns) // at least 1 row per batch
+	baseBytes := len(q.table) + len("INSERT INTO  () VALUES ")
+	for column := range q.rows[0] {
+		baseBytes += len(column) + len(", ")
+	}
+
+	d := dialect.Current()
+	batches := make([]*InsertRows, 0)
+	start, numBytes := 0, baseBytes
+	for i, row := range q.rows {
+		rowBytes := len("(), ")
+		for _, value := range row {
+			rowBytes += len(d.Literal(value)) + len(", ")
+		}
+		numParams := (i - start + 1) * numColumns
+		if i > start && (numParams > maxParams || numBytes+rowBytes > q.maxBytes) {
+			batches = append(batches, q.batch(start, i))
+			start, numBytes = i, baseBytes
+		}
+		numBytes += rowBytes
+	}
+	batches = append(batches, q.batch(start, len(q.rows)))
+	return q.cache.set(batches)
+}
+
+// Batches as list of queries, used by ExecBatches
+func (q InsertRows) BatchQueries() []Query {
+	return list.Map(q.Batches(), func(batch *InsertRows) Query {
+		return batch
+	})
+}
+
+// Create InsertRows Query for rows[start:end]
+func (q InsertRows) batch(start, end int) *InsertRows {
+	batch := q
+	batch.rows = q.rows[start:end]
+	batch.cache = nil // batch is not split further
+	return &batch
+}
+
+// Execute InsertRows or BulkUpdate Query in batches;
+// batches are not atomic: on error, earlier batches stay executed (use ExecBatchesTx for all-or-nothing)
+func ExecBatches(q BatchQuery, dbc *sql.DB) (*BatchResult, error) {
+	batches, err := preBatchCheck(q)
+	if err != nil {
+		return nil, err
+	}
+	result := &BatchResult{Results: make([]*sql.Result, 0, len(batches))}
+	for i, batch := range batches {
+		batchResult, err := Exec(batch, dbc)
+		if err != nil {
+			return result, fmt.Errorf("batch %d of %d: %w", i+1, len(batches), err)
+		}
+		result.Results = append(result.Results, batchResult)
+		result.RowsAffected += RowsAffected(batchResult)
+	}
+	return result, nil
+}
+
+// Execute InsertRows or BulkUpdate Query in batches as part of transaction;
+// checker is applied to each batch result, rollback on any errors
+func ExecBatchesTx(q BatchQuery, dbtx *sql.Tx, checker ResultChecker) (*BatchResult, error) {
+	batches, err := preBatchCheck(q)
+	if err != nil {
+		return nil, Rollback(dbtx, err)
+	}
+	result := &BatchResult{Results: make([]*sql.Result, 0, len(batches))}
+	for i, batch := range batches {
+		batchResult, err := ExecTx(batch, dbtx, checker)
+		if err != nil {
+			return nil, fmt.Errorf("batch %d of %d: %w", i+1, len(batches), err)
+		}
+		result.Results = append(result.Results, batchResult)
+		result.RowsAffected += RowsAffected(batchResult)
+	}
+	return result, nil
+}
+
+// Execute InsertRows Query in batches and get the generated IDs, in row order;
+// MySQL: consecutive IDs from the last insert ID (requires consecutive auto-increment lock mode, increment 1),
+// PostgreSQL and SQLite: uses RETURNING idColumn. Batches are not atomic (use InsertIDsTx for all-or-nothing)
+func InsertIDs(q *InsertRows, dbc *sql.DB, idColumn string) ([]uint, error) {
+	batches, err := preInsertBatchCheck(q)
+	if err == nil && dbc == nil {
+		err = observe(q, "", []any{}, time.Now(), 0, ErrNoDBConnection)
+	}
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(q.rows))
+	for i, batch := range batches {
+		batchIDs, err := insertBatchIDs(batch, dbc, idColumn)
+		if err != nil {
+			return ids, fmt.Errorf("batch %d of %d: %w", i+1, len(batches), err)
+		}
+		ids = append(ids, batchIDs...)
+	}
+	return ids, nil
+}
+
+// Execute InsertRows Query in batches as part of transaction and get the generated IDs, in row order;
+// see InsertIDs, rollback on any errors
+func InsertIDsTx(q *InsertRows, dbtx *sql.Tx, idColumn string) ([]uint, error) {
+	batches, err := preInsertBatchCheck(q)
+	if err == nil && dbtx == nil {
+		err = observe(q, "", []any{}, time.Now(), 0, ErrNoDBTx)
+	}
+	if err != nil {
+		return nil, Rollback(dbtx, err)
+	}
+	ids := make([]uint, 0, len(q.rows))
+	for i, batch := range batches {
+		batchIDs, err := insertBatchIDs(batch, dbtx, idColumn)
+		if err != nil {
+			err = fmt.Errorf("batch %d of %d: %w", i+1, len(batches), err)
+			return nil, Rollback(dbtx, err)
+		}
+		ids = append(ids, batchIDs...)
+	}
+	return ids, nil
+}
+
+// Execute one InsertRows batch and get its generated IDs, using *sql.DB or *sql.Tx;
+// RETURNING is set on a copy, so cached batches are not changed
+func insertBatchIDs(batch *InsertRows, dbc preparer, idColumn string) ([]uint, error) {
+	isMySQL := dialect.Current() == dialect.MySQL
+	q := *batch
+	if !isMySQL {
+		q.returning = idColumn
+	}
+	query, values, err := buildCheck(q)
+	if err == nil && !isMySQL && idColumn == "" {
+		err = fmt.Errorf("returning: %w", ErrUnknownField)
+	}
+	if err != nil {
+		return nil, observe(q, query, values, time.Now(), 0, err)
+	}
+
+	start := time.Now()
+	var ids []uint
+	var rowsAffected int
+	if isMySQL {
+		var result *sql.Result
+		result, err = execStatement(dbc, query, values)
+		rowsAffected = RowsAffected(result)
+		if err == nil {
+			ids, err = consecutiveIDs(result, len(q.rows))
+		}
+	} else {
+		ids, err = queryStatementIDs(dbc, query, values)
+		rowsAffected = len(ids)
+	}
+	if err == nil && len(ids) != len(q.rows) {
+		err = ErrFailedResultCheck
+	}
+	err = observe(q, query, values, start, rowsAffected, err)
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// Get consecutive IDs starting from the last insert ID, which is the first ID of a multi-row insert in MySQL
+func consecutiveIDs(result *sql.Result, numRows int) ([]uint, error) {
+	firstID, ok := LastInsertID(result)
+	if !ok || RowsAffected(result) != numRows {
+		return nil, ErrFailedResultCheck
+	}
+	ids := make([]uint, numRows)
+	for i := range numRows {
+		ids[i] = firstID + uint(i)
+	}
+	return ids, nil
+}
+
+// Prepare and execute statement with RETURNING id, and read the IDs
+func queryStatementIDs(dbc preparer, query string, values []any) ([]uint, error) {
+	stmt, err := dbc.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]uint, 0)
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	// RETURNING order is not guaranteed, generated IDs increase in row order
+	slices.Sort(ids)
+	return ids, rows.Err()
+}
+
+// Before batched execution, validate the full query so that no batch runs if any part is invalid
+func preBatchCheck(q BatchQuery) ([]Query, error) {
+	if dyn.IsNull(q) {
+		return nil, ErrEmptyQuery
+	}
+	if err := q.Validate(); err != nil {
+		return nil, observe(q, "", []any{}, time.Now(), 0, err)
+	}
+	return q.BatchQueries(), nil
+}
+
+// Before batched InsertRows execution, validate the full query so that no batch runs if any row is invalid
+func preInsertBatchCheck(q *InsertRows) ([]*InsertRows, error) {
+	if _, err := preBatchCheck(q); err != nil {
+		return nil, err
+	}
+	return q.Batches(), nil
+}
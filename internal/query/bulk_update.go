@@ -0,0 +1,204 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/roidaradal/fn/dyn"
+	"github.com/roidaradal/fn/list"
+	"github.com/roidaradal/fn/str"
+	"github.com/roidaradal/rdb/internal/rdb"
+)
+
+// Default number of keys per BulkUpdate statement
+const DefaultBulkUpdateSize int = 500
+
+// BulkUpdate Query: updates rows with per-row values, using
+// UPDATE table SET column = CASE key WHEN ? THEN ? ... ELSE column END WHERE key IN (...)
+type BulkUpdate[T any, K comparable] struct {
+	conditionQuery
+	typeName  string
+	keyField  string
+	keyColumn string
+	keys      []K                         // keys in order added
+	updates   map[K]map[string]*rdb.Value // {Key => {Column => Value}}
+	batchSize int
+	invalid   []error // problems found when adding updates
+	cache     *batchCache[BulkUpdate[T, K]]
+}
+
+// Create new BulkUpdate Query, with rows identified by key field
+func NewBulkUpdate[T any, K comparable](table string, keyFieldRef *K) *BulkUpdate[T, K] {
+	var t T
+	return NewBulkUpdateKey[T, K](table, rdb.GetFieldName(dyn.TypeOf(t), keyFieldRef))
+}
+
+// Create new BulkUpdate Query, with rows identified by key field name
+func NewBulkUpdateKey[T any, K comparable](table string, keyFieldName string) *BulkUpdate[T, K] {
+	var t T
+	q := &BulkUpdate[T, K]{}
+	q.initializeOptional(table)
+	q.typeName = dyn.TypeOf(t)
+	q.keyField = keyFieldName
+	q.keyColumn = rdb.GetFieldColumnName(q.typeName, keyFieldName)
+	q.keys = make([]K, 0)
+	q.updates = make(map[K]map[string]*rdb.Value)
+	q.batchSize = DefaultBulkUpdateSize
+	q.invalid = make([]error, 0)
+	q.cache = &batchCache[BulkUpdate[T, K]]{}
+	return q
+}
+
+// Set max number of keys per statement, used by Batches (0 = default)
+func (q *BulkUpdate[T, K]) BatchSize(size int) {
+	q.cache.reset()
+	q.batchSize = max(0, size)
+	if q.batchSize == 0 {
+		q.batchSize = DefaultBulkUpdateSize
+	}
+}
+
+// Add field=value update for row with given key
+func (q *BulkUpdate[T, K]) Update(key K, fieldName string, value any) {
+	pair := rdb.ColumnUpdate(q.typeName, fieldName, value)
+	if pair == nil {
+		err := fmt.Errorf("update %s.%s: %w", q.typeName, fieldName, ErrUnknownField)
+		q.invalid = append(q.invalid, err)
+		return
+	}
+	q.cache.reset()
+	if _, ok := q.updates[key]; !ok {
+		q.keys = append(q.keys, key)
+		q.updates[key] = make(map[string]*rdb.Value)
+	}
+	column, _ := pair.Tuple()
+	q.updates[key][column] = pair
+}
+
+// Add field updates (new values) for row with given key
+func (q *BulkUpdate[T, K]) Updates(key K, updates FieldUpdates) {
+	for fieldName, update := range updates {
+		_, newValue := update.Tuple()
+		q.Update(key, fieldName, newValue)
+	}
+}
+
+// Add updates from items: each item's key field identifies the row, and the given fields are set to the item's values
+func (q *BulkUpdate[T, K]) Items(items []*T, fieldNames ...string) {
+	for i, item := range items {
+		var key K
+		ok := item != nil
+		if ok {
+			key, ok = dyn.GetFieldValue(item, q.keyField).(K)
+		}
+		if !ok {
+			err := fmt.Errorf("item #%d key %s: %w", i+1, q.keyField, ErrUnknownField)
+			q.invalid = append(q.invalid, err)
+			continue
+		}
+		for _, fieldName := range fieldNames {
+			q.Update(key, fieldName, dyn.GetFieldValue(item, fieldName))
+		}
+	}
+}
+
+// Split BulkUpdate Query into batches of at most batchSize keys,
+// also within the placeholder limit. Batches are computed once, until updates change
+func (q BulkUpdate[T, K]) Batches() []*BulkUpdate[T, K] {
+	if batches := q.cache.get(); batches != nil {
+		return batches
+	}
+	numColumns := max(1, len(q.columns()))
+	size := min(q.batchSize, DefaultMaxParams/(2*numColumns+1))
+	size = max(1, size)
+	if len(q.keys) <= size {
+		return q.cache.set([]*BulkUpdate[T, K]{&q})
+	}
+	batches := make([]*BulkUpdate[T, K], 0)
+	for start := 0; start < len(q.keys); start += size {
+		batch := q
+		batch.keys = q.keys[start:min(start+size, len(q.keys))]
+		batch.cache = nil // batch is not split further
+		batches = append(batches, &batch)
+	}
+	return q.cache.set(batches)
+}
+
+// Batches as list of queries, used by ExecBatches
+func (q BulkUpdate[T, K]) BatchQueries() []Query {
+	return list.Map(q.Batches(), func(batch *BulkUpdate[T, K]) Query {
+		return batch
+	})
+}
+
+// Build BulkUpdate Query
+func (q BulkUpdate[T, K]) Build() (string, []any) {
+	condition, conditionValues, err := q.conditionQuery.preBuildCheck()
+	columns := q.columns()
+	if err != nil || q.keyColumn == "" || len(q.keys) == 0 || len(columns) == 0 || len(q.invalid) > 0 {
+		return emptyQueryValues()
+	}
+	values := make([]any, 0)
+	updates := make([]string, 0, len(columns))
+	for _, column := range columns {
+		cases := make([]string, 0, len(q.keys))
+		for _, key := range q.keys {
+			pair, ok := q.updates[key][column]
+			if !ok {
+				continue // keeps current value
+			}
+			_, value := pair.Tuple()
+			cases = append(cases, "WHEN ? THEN ?")
+			values = append(values, q.keyValue(key), value)
+		}
+		if len(cases) == 0 {
+			continue // column not updated in this batch
+		}
+		update := fmt.Sprintf("%s = CASE %s %s ELSE %s END", column, q.keyColumn, strings.Join(cases, " "), column)
+		updates = append(updates, update)
+	}
+	for _, key := range q.keys {
+		values = append(values, q.keyValue(key))
+	}
+	values = append(values, conditionValues...)
+	placeholders := str.Repeat(len(q.keys), "?", ", ")
+	query := "UPDATE %s SET %s WHERE %s IN (%s) AND %s"
+	query = fmt.Sprintf(query, q.table, strings.Join(updates, ", "), q.keyColumn, placeholders, condition)
+	return query, values
+}
+
+// Validate BulkUpdate Query
+func (q BulkUpdate[T, K]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, fieldProblems("key", q.keyColumn)...)
+	if len(q.keys) == 0 {
+		problems = append(problems, ErrMissingRows)
+	}
+	problems = append(problems, q.invalid...)
+	return newValidationError(problems)
+}
+
+// Get updated columns of the batch's keys, in sorted order
+func (q BulkUpdate[T, K]) columns() []string {
+	columns := make([]string, 0)
+	for _, key := range q.keys {
+		for column := range q.updates[key] {
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+	slices.Sort(columns)
+	return columns
+}
+
+// Get key's column value, applying the key column's options
+func (q BulkUpdate[T, K]) keyValue(key K) any {
+	pair := rdb.ColumnValue(q.typeName, q.keyField, key)
+	if pair == nil {
+		return key
+	}
+	_, value := pair.Tuple()
+	return value
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"database/sql"
 	"fmt"
+	"time"
 )
 
 // Count Query
@@ -28,6 +29,11 @@ func (q Count) Build() (string, []any) {
 	return query, values
 }
 
+// Validate Count Query
+func (q Count) Validate() error {
+	return newValidationError(q.conditionQuery.problems())
+}
+
 // Execute CountQuery and get count
 func (q Count) Count(dbc *sql.DB) (int, error) {
 	query, values, err := preQueryCheck(q, dbc)
@@ -35,7 +41,9 @@ func (q Count) Count(dbc *sql.DB) (int, error) {
 		return 0, err
 	}
 	count := 0
+	start := time.Now()
 	err = dbc.QueryRow(query, values...).Scan(&count)
+	err = observe(q, query, values, start, 0, err)
 	if err != nil {
 		return 0, err
 	}
@@ -1,10 +1,17 @@
 package query
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/roidaradal/rdb/internal/dialect"
+)
 
 // Delete Query
 type Delete struct {
 	conditionQuery
+	rowsGuard
+	order string
+	limit uint
 }
 
 // Create new Delete Query
@@ -14,13 +21,76 @@ func NewDelete(table string) *Delete {
 	return q
 }
 
+// Set Delete column order (ascending), requires Limit
+func (q *Delete) OrderAsc(column string) {
+	q.order = fmt.Sprintf("%s ASC", column)
+}
+
+// Set Delete column order (descending), requires Limit
+func (q *Delete) OrderDesc(column string) {
+	q.order = fmt.Sprintf("%s DESC", column)
+}
+
+// Add Delete column order (ascending), after existing order
+func (q *Delete) ThenAsc(column string) {
+	q.addOrder(fmt.Sprintf("%s ASC", column))
+}
+
+// Add Delete column order (descending), after existing order
+func (q *Delete) ThenDesc(column string) {
+	q.addOrder(fmt.Sprintf("%s DESC", column))
+}
+
+// Set limit for Delete Query
+func (q *Delete) Limit(limit uint) {
+	q.limit = limit
+}
+
+// Append to Delete order
+func (q *Delete) addOrder(order string) {
+	if q.order == "" {
+		q.order = order
+	} else {
+		q.order = fmt.Sprintf("%s, %s", q.order, order)
+	}
+}
+
 // Build Delete Query
 func (q Delete) Build() (string, []any) {
 	condition, values, err := q.conditionQuery.preBuildCheck()
-	if err != nil {
-		return emptyQueryValues()
+	if err != nil || (q.order != "" && q.limit == 0) {
+		return emptyQueryValues() // order without limit is invalid
 	}
 	query := "DELETE FROM %s WHERE %s"
 	query = fmt.Sprintf(query, q.table, condition)
+	if q.limit == 0 {
+		return query, values
+	}
+	rowLimit := fmt.Sprintf("LIMIT %d", q.limit)
+	if q.order != "" {
+		rowLimit = fmt.Sprintf("ORDER BY %s %s", q.order, rowLimit)
+	}
+	switch dialect.Current() {
+	case dialect.PostgreSQL:
+		// PostgreSQL has no DELETE ... LIMIT, select the physical row IDs to delete
+		query = "DELETE FROM %s WHERE ctid IN (SELECT ctid FROM %s WHERE %s %s)"
+		query = fmt.Sprintf(query, q.table, q.table, condition, rowLimit)
+	case dialect.SQLite:
+		// SQLite only supports DELETE ... LIMIT if compiled with the option, use rowid
+		query = "DELETE FROM %s WHERE rowid IN (SELECT rowid FROM %s WHERE %s %s)"
+		query = fmt.Sprintf(query, q.table, q.table, condition, rowLimit)
+	default:
+		query = fmt.Sprintf("%s %s", query, rowLimit)
+	}
 	return query, values
 }
+
+// Validate Delete Query
+func (q Delete) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, q.conditionQuery.fullTableProblems()...)
+	if q.order != "" && q.limit == 0 {
+		problems = append(problems, fmt.Errorf("order: %w", ErrMissingLimit))
+	}
+	return newValidationError(problems)
+}
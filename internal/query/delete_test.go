@@ -0,0 +1,35 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/roidaradal/rdb/internal/condition"
+	"github.com/roidaradal/rdb/internal/rdb"
+)
+
+func TestDeleteOrderRequiresLimit(t *testing.T) {
+	rdb.Initialize()
+	e := &event{}
+	if err := rdb.AddType(e); err != nil {
+		t.Fatal(err)
+	}
+	q := NewDelete("events")
+	q.Where(condition.NewValue(&e.Name, "x", condition.Equal))
+	q.OrderAsc(rdb.GetColumnName(&e.ID))
+	if err := q.Validate(); !errors.Is(err, ErrMissingLimit) {
+		t.Errorf("Validate: got %v, want %v", err, ErrMissingLimit)
+	}
+	if query, _ := q.Build(); query != "" {
+		t.Errorf("Build: got %q, want empty query", query)
+	}
+
+	q.Limit(2)
+	if err := q.Validate(); err != nil {
+		t.Errorf("Validate: got %v, want nil", err)
+	}
+	want := "DELETE FROM `events` WHERE `Name` = ? ORDER BY `ID` ASC LIMIT 2"
+	if query, _ := q.Build(); query != want {
+		t.Errorf("Build: got %q, want %q", query, want)
+	}
+}
@@ -38,6 +38,13 @@ func (q DistinctValues[T, V]) Build() (string, []any) {
 	return query, values
 }
 
+// Validate DistinctValues Query
+func (q DistinctValues[T, V]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, fieldProblems("value", q.columnName)...)
+	return newValidationError(problems)
+}
+
 // Execute DistinctValues Query and get list of distinct values
 func (q DistinctValues[T, V]) Query(dbc *sql.DB) ([]V, error) {
 	query, values, err := preReadCheck(q, dbc, q.reader)
@@ -46,7 +53,7 @@ func (q DistinctValues[T, V]) Query(dbc *sql.DB) ([]V, error) {
 	}
 
 	distinct := make([]V, 0)
-	err = readRows(dbc, query, values, q.reader, func(item *T) {
+	err = readRows(q, dbc, query, values, q.reader, func(item *T) {
 		value, err := getTypedColumnValue[V](item, q.typeName, q.columnName)
 		if err != nil {
 			return
@@ -0,0 +1,224 @@
+package query
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/roidaradal/fn/list"
+)
+
+var (
+	ErrEmptyQuery          = errors.New("empty query")
+	ErrFailedResultCheck   = errors.New("result check failed")
+	ErrFailedTypeAssertion = errors.New("type assertion failed")
+	ErrNoChecker           = errors.New("no result checker")
+	ErrNoDBConnection      = errors.New("no db connection")
+	ErrNoDBTx              = errors.New("no db transaction")
+	ErrNoReader            = errors.New("no row reader")
+	ErrNotFoundField       = errors.New("field not found")
+	ErrTooManyRows         = errors.New("too many rows affected")
+)
+
+// Build failure reasons
+var (
+	ErrMissingTable      = errors.New("missing table")
+	ErrMissingColumns    = errors.New("missing columns")
+	ErrMissingCondition  = errors.New("missing condition")
+	ErrMissingOrder      = errors.New("missing order")
+	ErrMissingRows       = errors.New("missing rows")
+	ErrUnknownField      = errors.New("unknown field reference")
+	ErrUnknownOperator   = errors.New("unknown operator")
+	ErrMismatchedColumns = errors.New("mismatched row columns")
+	ErrFullTable         = errors.New("condition matches all rows")
+	ErrInvalidBucket     = errors.New("invalid time bucket")
+	ErrMissingLimit      = errors.New("missing limit")
+)
+
+// Query that can list its build problems before building
+type validator interface {
+	Validate() error
+}
+
+// Validation error, lists each problem that prevents the query from being built
+type ValidationError struct {
+	Problems []error
+}
+
+// Return error message, joining all problems
+func (e *ValidationError) Error() string {
+	messages := list.Map(e.Problems, func(err error) string {
+		return err.Error()
+	})
+	return fmt.Sprintf("invalid query: %s", strings.Join(messages, "; "))
+}
+
+// Return list of problems, for errors.Is and errors.As
+func (e *ValidationError) Unwrap() []error {
+	return e.Problems
+}
+
+// Create ValidationError from list of problems; returns nil if no problems
+func newValidationError(problems []error) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: problems}
+}
+
+// Query error, with the operation, table, built SQL and arguments
+type QueryError struct {
+	Op    string // query type, e.g. SelectRows
+	Table string // table name
+	SQL   string // built query string (blank if build failed)
+	Args  []any  // query parameter values
+	Err   error  // failure reason or driver error
+}
+
+// Return error message, without SQL and arguments
+func (e *QueryError) Error() string {
+	if e.Table == "" {
+		return fmt.Sprintf("%s query: %v", e.Op, e.Err)
+	}
+	return fmt.Sprintf("%s query on %s: %v", e.Op, e.Table, e.Err)
+}
+
+// Return underlying error
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// Wrap error into QueryError, with query details; returns nil if no error
+func newQueryError(q Query, query string, values []any, err error) error {
+	if err == nil {
+		return nil
+	}
+	var qe *QueryError
+	if errors.As(err, &qe) {
+		return err // already wrapped
+	}
+	labels := queryLabels(q)
+	return &QueryError{
+		Op:    labels.Operation,
+		Table: labels.Table,
+		SQL:   query,
+		Args:  values,
+		Err:   err,
+	}
+}
+
+// Check if error is a duplicate unique key violation
+func IsDuplicateKey(err error) bool {
+	return hasMySQLNumber(err, 1062, 1586) ||
+		hasSQLState(err, "23505") ||
+		hasMessage(err, "UNIQUE constraint failed")
+}
+
+// Check if error is a foreign key violation
+func IsForeignKeyViolation(err error) bool {
+	return hasMySQLNumber(err, 1216, 1217, 1451, 1452) ||
+		hasSQLState(err, "23503") ||
+		hasMessage(err, "FOREIGN KEY constraint failed")
+}
+
+// Check if error is a deadlock or serialization failure
+func IsDeadlock(err error) bool {
+	return hasMySQLNumber(err, 1213) ||
+		hasSQLState(err, "40P01", "40001")
+}
+
+// Check if error is a check constraint violation
+func IsCheckViolation(err error) bool {
+	return hasMySQLNumber(err, 3819) ||
+		hasSQLState(err, "23514") ||
+		hasMessage(err, "CHECK constraint failed")
+}
+
+// Check if error is a NOT NULL violation
+func IsNotNullViolation(err error) bool {
+	return hasMySQLNumber(err, 1048, 1364) ||
+		hasSQLState(err, "23502") ||
+		hasMessage(err, "NOT NULL constraint failed")
+}
+
+// Check if error is a data too long error
+func IsDataTooLong(err error) bool {
+	return hasMySQLNumber(err, 1406) ||
+		hasSQLState(err, "22001")
+}
+
+// Get the column, key, or constraint name mentioned in a driver error message, if any.
+// Keys and constraints are often named after the column they cover
+func ErrorColumn(err error) string {
+	if err == nil {
+		return ""
+	}
+	message := err.Error()
+	for _, pattern := range errorColumnPatterns {
+		match := pattern.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		name := strings.Trim(match[1], "`\"' ")
+		name, _, _ = strings.Cut(name, ",") // first column of composite key
+		name = strings.Trim(name, "`\"' ")
+		if idx := strings.LastIndex(name, "."); idx >= 0 {
+			name = name[idx+1:] // remove table prefix
+		}
+		return name
+	}
+	return ""
+}
+
+// Check if error is a no rows found error
+func IsNotFound(err error) bool {
+	return errors.Is(err, sql.ErrNoRows)
+}
+
+// Patterns for extracting column, key, or constraint name from driver error messages
+var errorColumnPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`FOREIGN KEY \(([^)]+)\)`),     // MySQL foreign key
+	regexp.MustCompile(`for key '([^']+)'`),           // MySQL duplicate entry
+	regexp.MustCompile(`(?i)column '([^']+)'`),        // MySQL data too long, cannot be null
+	regexp.MustCompile(`(?i)constraint '([^']+)'`),    // MySQL check constraint
+	regexp.MustCompile(`in column "([^"]+)"`),         // PostgreSQL not null
+	regexp.MustCompile(`constraint "([^"]+)"`),        // PostgreSQL constraints
+	regexp.MustCompile(`constraint failed: ([\w.]+)`), // SQLite constraints
+}
+
+// Check if error is a MySQL error with any of the given error numbers
+func hasMySQLNumber(err error, numbers ...uint16) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	for _, number := range numbers {
+		if mysqlErr.Number == number {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if error has any of the given SQLSTATE codes (e.g. PostgreSQL drivers)
+func hasSQLState(err error, states ...string) bool {
+	var stateErr interface{ SQLState() string }
+	if !errors.As(err, &stateErr) {
+		return false
+	}
+	code := stateErr.SQLState()
+	for _, state := range states {
+		if code == state {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if error message contains the given text (e.g. SQLite drivers)
+func hasMessage(err error, text string) bool {
+	return err != nil && strings.Contains(err.Error(), text)
+}
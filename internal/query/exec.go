@@ -2,22 +2,17 @@ package query
 
 import (
 	"database/sql"
-	"errors"
 	"fmt"
-)
+	"time"
 
-var (
-	errEmptyQuery          = errors.New("empty query")
-	errEmptyTable          = errors.New("empty table")
-	errFailedResultCheck   = errors.New("result check failed")
-	errFailedTypeAssertion = errors.New("type assertion failed")
-	errNoChecker           = errors.New("no result checker")
-	errNoDBConnection      = errors.New("no db connection")
-	errNoDBTx              = errors.New("no db transaction")
-	errNoReader            = errors.New("no row reader")
-	errNotFoundField       = errors.New("field not found")
+	"github.com/roidaradal/rdb/internal/metrics"
 )
 
+// Interface for *sql.DB and *sql.Tx
+type preparer interface {
+	Prepare(string) (*sql.Stmt, error)
+}
+
 // Checks SQL result if condition is satisfied
 type ResultChecker func(*sql.Result) bool
 
@@ -65,6 +60,18 @@ func Exec(q Query, dbc *sql.DB) (*sql.Result, error) {
 	if err != nil {
 		return nil, err
 	}
+	if maxRowsOf(q) > 0 {
+		// Guarded query runs in its own transaction, so it can be rolled back
+		return ExecChecked(q, dbc, AssertNothing)
+	}
+	start := time.Now()
+	result, err := execStatement(dbc, query, values)
+	err = observe(q, query, values, start, RowsAffected(result), err)
+	return result, err
+}
+
+// Prepare and execute statement, using *sql.DB or *sql.Tx
+func execStatement(dbc preparer, query string, values []any) (*sql.Result, error) {
 	stmt, err := dbc.Prepare(query)
 	if err != nil {
 		return nil, err
@@ -81,44 +88,74 @@ func Exec(q Query, dbc *sql.DB) (*sql.Result, error) {
 // Execute SQL query as part of transaction.
 // Applies Rollback on any errors
 func ExecTx(q Query, dbtx *sql.Tx, checker ResultChecker) (*sql.Result, error) {
-	var err error = nil
-	query, values := q.Build()
-	if dbtx == nil {
-		err = errNoDBTx
-	} else if query == "" {
-		err = errEmptyQuery
-	} else if checker == nil {
-		err = errNoChecker
+	query, values, err := buildCheck(q)
+	if err == nil {
+		if dbtx == nil {
+			err = ErrNoDBTx
+		} else if checker == nil {
+			err = ErrNoChecker
+		}
 	}
 	if err != nil {
+		err = observe(q, query, values, time.Now(), 0, err)
 		return nil, Rollback(dbtx, err)
 	}
 
-	stmt, err := dbtx.Prepare(query)
+	start := time.Now()
+	result, err := execStatement(dbtx, query, values)
+	err = observe(q, query, values, start, RowsAffected(result), err)
 	if err != nil {
 		return nil, Rollback(dbtx, err)
 	}
-	defer stmt.Close()
 
-	result, err := stmt.Exec(values...)
-	if err != nil {
+	if err := checkRowsAffected(q, RowsAffected(result)); err != nil {
+		err = newQueryError(q, query, values, err)
 		return nil, Rollback(dbtx, err)
 	}
 
-	if ok := checker(&result); !ok {
-		return nil, Rollback(dbtx, errFailedResultCheck)
+	if ok := checker(result); !ok {
+		err = newQueryError(q, query, values, ErrFailedResultCheck)
+		return nil, Rollback(dbtx, err)
 	}
 
-	return &result, nil
+	return result, nil
+}
+
+// Execute SQL query in its own transaction and apply checker to the result:
+// commits if the check passes, otherwise rolls back (e.g. guard a delete outside of transactions)
+func ExecChecked(q Query, dbc *sql.DB, checker ResultChecker) (*sql.Result, error) {
+	if dbc == nil {
+		return nil, observe(q, "", []any{}, time.Now(), 0, ErrNoDBConnection)
+	}
+	dbtx, err := dbc.Begin()
+	if err != nil {
+		return nil, err
+	}
+	result, err := ExecTx(q, dbtx, checker)
+	if err != nil {
+		return nil, err // already rolled back
+	}
+	err = dbtx.Commit()
+	if err != nil {
+		metrics.ObserveTransaction(metrics.OutcomeFailed)
+		return nil, fmt.Errorf("dbtx commit error: %w", err)
+	}
+	metrics.ObserveTransaction(metrics.OutcomeCommit)
+	return result, nil
 }
 
 // Rollback SQL transaction
 func Rollback(dbtx *sql.Tx, err error) error {
+	if dbtx == nil {
+		return err // no transaction to rollback
+	}
 	err2 := dbtx.Rollback()
 	if err2 != nil {
+		metrics.ObserveTransaction(metrics.OutcomeFailed)
 		// Combine original error and rollback error
 		return fmt.Errorf("error: %w, rollback error: %w", err, err2)
 	}
+	metrics.ObserveTransaction(metrics.OutcomeRollback)
 	// return original error if rollback successful
 	return err
 }
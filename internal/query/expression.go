@@ -0,0 +1,130 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roidaradal/rdb/internal/condition"
+	"github.com/roidaradal/rdb/internal/rdb"
+)
+
+// Update of column to SQL expression, with placeholder values
+type updateExpression struct {
+	column     string
+	expression string
+	values     []any
+}
+
+// CASE WHEN condition THEN value branch, used in case updates
+type When[V any] struct {
+	Condition condition.Condition
+	Value     V
+}
+
+// Add field = field + amount update to Update Query
+func AddIncrementUpdate[T any, V Number](q *Update[T], fieldRef *V, amount V) {
+	// Note: Cannot be method as generics are not supported in methods
+	q.addArithmetic("increment", rdb.GetColumnName(fieldRef), "+", amount)
+}
+
+// Add field = field - amount update to Update Query
+func AddDecrementUpdate[T any, V Number](q *Update[T], fieldRef *V, amount V) {
+	// Note: Cannot be method as generics are not supported in methods
+	q.addArithmetic("decrement", rdb.GetColumnName(fieldRef), "-", amount)
+}
+
+// Add field = field * factor update to Update Query
+func AddMultiplyUpdate[T any, V Number](q *Update[T], fieldRef *V, factor V) {
+	// Note: Cannot be method as generics are not supported in methods
+	q.addArithmetic("multiply", rdb.GetColumnName(fieldRef), "*", factor)
+}
+
+// Add field = sourceField update to Update Query
+func AddColumnUpdate[T, V any](q *Update[T], fieldRef, sourceFieldRef *V) {
+	// Note: Cannot be method as generics are not supported in methods
+	source := rdb.GetColumnName(sourceFieldRef)
+	if source == "" {
+		q.addExpressionProblem("column source", ErrUnknownField)
+		return
+	}
+	q.addExpression("column", rdb.GetColumnName(fieldRef), source)
+}
+
+// Add field = CURRENT_TIMESTAMP update to Update Query
+func AddNowUpdate[T any](q *Update[T], fieldRef any) {
+	// Note: Cannot be method as generics are not supported in methods
+	q.addExpression("now", rdb.GetColumnName(fieldRef), "CURRENT_TIMESTAMP")
+}
+
+// Add field = COALESCE(field, value) update to Update Query: only sets value if field is NULL
+func AddCoalesceUpdate[T, V any](q *Update[T], fieldRef *V, value V) {
+	// Note: Cannot be method as generics are not supported in methods
+	column := rdb.GetColumnName(fieldRef)
+	q.addExpression("coalesce", column, fmt.Sprintf("COALESCE(%s, ?)", column), columnValue(fieldRef, value))
+}
+
+// Add field = CASE WHEN condition THEN value ... ELSE field END update to Update Query:
+// first matching condition sets the value, field is unchanged if no condition matches
+func AddCaseUpdate[T, V any](q *Update[T], fieldRef *V, whens ...When[V]) {
+	// Note: Cannot be method as generics are not supported in methods
+	column := rdb.GetColumnName(fieldRef)
+	if len(whens) == 0 {
+		q.addExpressionProblem("case", ErrMissingCondition)
+		return
+	}
+	branches := make([]string, 0, len(whens))
+	values := make([]any, 0, 2*len(whens))
+	for _, when := range whens {
+		if when.Condition == nil {
+			q.addExpressionProblem("case", ErrMissingCondition)
+			return
+		}
+		whenCondition, whenValues := when.Condition.Build()
+		branches = append(branches, fmt.Sprintf("WHEN %s THEN ?", whenCondition))
+		values = append(values, whenValues...)
+		values = append(values, columnValue(fieldRef, when.Value))
+	}
+	expression := fmt.Sprintf("CASE %s ELSE %s END", strings.Join(branches, " "), column)
+	q.addExpression("case", column, expression, values...)
+}
+
+// Add column = column + amount update to Update Query
+func (q *Update[T]) Increment(fieldName string, amount any) {
+	q.addArithmetic("increment", rdb.GetFieldColumnName(q.typeName, fieldName), "+", amount)
+}
+
+// Add column = column - amount update to Update Query
+func (q *Update[T]) Decrement(fieldName string, amount any) {
+	q.addArithmetic("decrement", rdb.GetFieldColumnName(q.typeName, fieldName), "-", amount)
+}
+
+// Add column = column <operator> ? update
+func (q *Update[T]) addArithmetic(label, column, operator string, value any) {
+	expression := fmt.Sprintf("%s %s ?", column, operator)
+	q.addExpression(label, column, expression, value)
+}
+
+// Add column = expression update
+func (q *Update[T]) addExpression(label, column, expression string, values ...any) {
+	if column == "" {
+		q.addExpressionProblem(label, ErrUnknownField)
+		return
+	}
+	q.exprs = append(q.exprs, updateExpression{column, expression, values})
+}
+
+// Record problem found when adding expression update
+func (q *Update[T]) addExpressionProblem(label string, err error) {
+	number := len(q.updates) + len(q.exprs) + 1
+	q.invalid = append(q.invalid, fmt.Errorf("%s update #%d: %w", label, number, err))
+}
+
+// Get field value as column value for updates, applying column options
+func columnValue[V any](fieldRef *V, value V) any {
+	pair := rdb.KeyUpdate(fieldRef, value)
+	if pair == nil {
+		return value
+	}
+	_, columnValue := pair.Tuple()
+	return columnValue
+}
@@ -3,7 +3,12 @@ package query
 import (
 	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/roidaradal/fn/list"
+	"github.com/roidaradal/rdb/internal/condition"
 	"github.com/roidaradal/rdb/internal/rdb"
 )
 
@@ -11,23 +16,52 @@ type Number interface {
 	~int | ~uint | ~int64 | ~float32 | ~float64
 }
 
+// Operators allowed in group HAVING conditions
+var havingOperators = []string{
+	condition.Equal, condition.NotEqual, condition.Greater,
+	condition.GreaterEqual, condition.Less, condition.LessEqual,
+}
+
+// Group key and aggregate value
+type Group[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
 // Group Count Query
 type GroupCount[K comparable] struct {
 	conditionQuery
+	groupOptions
 	groupColumn string
 }
 
 // Group Sum Query
 type GroupSum[K comparable, V Number] struct {
 	conditionQuery
+	groupOptions
 	groupColumn string
 	sumColumn   string
 }
 
+// HAVING conditions, ordering and limit of group queries
+type groupOptions struct {
+	having       []string // HAVING operators, applied to the aggregate
+	havingValues []any
+	orders       []groupOrder
+	limit        uint
+}
+
+// Group order: by key or by aggregate value
+type groupOrder struct {
+	byValue bool
+	desc    bool
+}
+
 // Create new GroupCount Query
 func NewGroupCount[K comparable](table string, groupFieldRef *K) *GroupCount[K] {
 	q := &GroupCount[K]{}
 	q.initializeOptional(table)
+	q.initializeGroupOptions()
 	q.groupColumn = rdb.GetColumnName(groupFieldRef)
 	return q
 }
@@ -36,12 +70,99 @@ func NewGroupCount[K comparable](table string, groupFieldRef *K) *GroupCount[K]
 func NewGroupSum[K comparable, V Number](table string, groupFieldRef *K, sumFieldRef *V) *GroupSum[K, V] {
 	q := &GroupSum[K, V]{}
 	q.initializeOptional(table)
-	columns := rdb.GetColumnNames(groupFieldRef, sumFieldRef)
-	q.groupColumn = columns[0]
-	q.sumColumn = columns[1]
+	q.initializeGroupOptions()
+	q.groupColumn = rdb.GetColumnName(groupFieldRef)
+	q.sumColumn = rdb.GetColumnName(sumFieldRef)
 	return q
 }
 
+// Add HAVING COUNT(*) <operator> count condition, operator is one of =, !=, >, >=, <, <=
+func (q *GroupCount[K]) Having(operator string, count int) {
+	q.addHaving(operator, count)
+}
+
+// Add HAVING SUM(column) <operator> sum condition, operator is one of =, !=, >, >=, <, <=
+func (q *GroupSum[K, V]) Having(operator string, sum V) {
+	q.addHaving(operator, sum)
+}
+
+// Initialize group options
+func (o *groupOptions) initializeGroupOptions() {
+	o.having = make([]string, 0)
+	o.havingValues = make([]any, 0)
+	o.orders = make([]groupOrder, 0)
+}
+
+// Add order by group key (ascending), after existing order
+func (o *groupOptions) OrderKeyAsc() {
+	o.orders = append(o.orders, groupOrder{byValue: false, desc: false})
+}
+
+// Add order by group key (descending), after existing order
+func (o *groupOptions) OrderKeyDesc() {
+	o.orders = append(o.orders, groupOrder{byValue: false, desc: true})
+}
+
+// Add order by aggregate value (ascending), after existing order
+func (o *groupOptions) OrderValueAsc() {
+	o.orders = append(o.orders, groupOrder{byValue: true, desc: false})
+}
+
+// Add order by aggregate value (descending), after existing order
+func (o *groupOptions) OrderValueDesc() {
+	o.orders = append(o.orders, groupOrder{byValue: true, desc: true})
+}
+
+// Set group limit
+func (o *groupOptions) Limit(limit uint) {
+	o.limit = limit
+}
+
+// Add HAVING condition on the aggregate
+func (o *groupOptions) addHaving(operator string, value any) {
+	o.having = append(o.having, operator)
+	o.havingValues = append(o.havingValues, value)
+}
+
+// Append HAVING, ORDER BY and LIMIT clauses to group query
+func (o groupOptions) build(query string, values []any, groupColumn, aggregate string) (string, []any) {
+	if len(o.having) > 0 {
+		having := list.Map(o.having, func(operator string) string {
+			return fmt.Sprintf("%s %s ?", aggregate, operator)
+		})
+		query = fmt.Sprintf("%s HAVING %s", query, strings.Join(having, " AND "))
+		values = append(values, o.havingValues...)
+	}
+	if len(o.orders) > 0 {
+		orders := list.Map(o.orders, func(order groupOrder) string {
+			column := groupColumn
+			if order.byValue {
+				column = aggregate
+			}
+			if order.desc {
+				return fmt.Sprintf("%s DESC", column)
+			}
+			return fmt.Sprintf("%s ASC", column)
+		})
+		query = fmt.Sprintf("%s ORDER BY %s", query, strings.Join(orders, ", "))
+	}
+	if o.limit > 0 {
+		query = fmt.Sprintf("%s LIMIT %d", query, o.limit)
+	}
+	return query, values
+}
+
+// List group option problems
+func (o groupOptions) problems() []error {
+	problems := make([]error, 0)
+	for i, operator := range o.having {
+		if !slices.Contains(havingOperators, operator) {
+			problems = append(problems, fmt.Errorf("having #%d: %w: %q", i+1, ErrUnknownOperator, operator))
+		}
+	}
+	return problems
+}
+
 // Build GroupCount Query
 func (q GroupCount[K]) Build() (string, []any) {
 	condition, values, err := q.conditionQuery.preBuildCheck()
@@ -50,7 +171,7 @@ func (q GroupCount[K]) Build() (string, []any) {
 	}
 	query := "SELECT %s, COUNT(*) FROM %s WHERE %s GROUP BY %s"
 	query = fmt.Sprintf(query, q.groupColumn, q.table, condition, q.groupColumn)
-	return query, values
+	return q.groupOptions.build(query, values, q.groupColumn, "COUNT(*)")
 }
 
 // Build GroupSum Query
@@ -61,64 +182,92 @@ func (q GroupSum[K, V]) Build() (string, []any) {
 	}
 	query := "SELECT %s, SUM(%s) FROM %s WHERE %s GROUP BY %s"
 	query = fmt.Sprintf(query, q.groupColumn, q.sumColumn, q.table, condition, q.groupColumn)
-	return query, values
+	sum := fmt.Sprintf("SUM(%s)", q.sumColumn)
+	return q.groupOptions.build(query, values, q.groupColumn, sum)
+}
+
+// Validate GroupCount Query
+func (q GroupCount[K]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, fieldProblems("group", q.groupColumn)...)
+	problems = append(problems, q.groupOptions.problems()...)
+	return newValidationError(problems)
+}
+
+// Validate GroupSum Query
+func (q GroupSum[K, V]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, fieldProblems("group", q.groupColumn)...)
+	problems = append(problems, fieldProblems("sum", q.sumColumn)...)
+	problems = append(problems, q.groupOptions.problems()...)
+	return newValidationError(problems)
 }
 
 // Execute GroupCountQuery and get map[group]count
 func (q GroupCount[K]) GroupCount(dbc *sql.DB) (map[K]int, error) {
-	query, values, err := preQueryCheck(q, dbc)
+	groups, err := readGroups[K, int](q, dbc)
 	if err != nil {
 		return nil, err
 	}
+	return groupMap(groups), nil
+}
 
-	rows, err := dbc.Query(query, values...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+// Execute GroupCountQuery and get list of (group, count), in query order
+func (q GroupCount[K]) GroupCountList(dbc *sql.DB) ([]Group[K, int], error) {
+	return readGroups[K, int](q, dbc)
+}
 
-	counts := make(map[K]int)
-	for rows.Next() {
-		var key K
-		var count int
-		err = rows.Scan(&key, &count)
-		if err != nil {
-			continue
-		}
-		counts[key] = count
-	}
-	if err = rows.Err(); err != nil {
+// Execute GroupSumQuery and get map[group]sum
+func (q GroupSum[K, V]) GroupSum(dbc *sql.DB) (map[K]V, error) {
+	groups, err := readGroups[K, V](q, dbc)
+	if err != nil {
 		return nil, err
 	}
+	return groupMap(groups), nil
+}
 
-	return counts, nil
+// Execute GroupSumQuery and get list of (group, sum), in query order
+func (q GroupSum[K, V]) GroupSumList(dbc *sql.DB) ([]Group[K, V], error) {
+	return readGroups[K, V](q, dbc)
 }
 
-// Execute GroupSumQuery and get map[group]sum
-func (q GroupSum[K, V]) GroupSum(dbc *sql.DB) (map[K]V, error) {
+// Execute group query and read (key, value) rows
+func readGroups[K comparable, V any](q Query, dbc *sql.DB) ([]Group[K, V], error) {
 	query, values, err := preQueryCheck(q, dbc)
 	if err != nil {
 		return nil, err
 	}
 
+	start := time.Now()
 	rows, err := dbc.Query(query, values...)
 	if err != nil {
+		err = observe(q, query, values, start, 0, err)
 		return nil, err
 	}
 	defer rows.Close()
 
-	sums := make(map[K]V)
+	groups := make([]Group[K, V], 0)
 	for rows.Next() {
-		var key K
-		var sum V
-		err = rows.Scan(&key, &sum)
+		var group Group[K, V]
+		err = rows.Scan(&group.Key, &group.Value)
 		if err != nil {
 			continue
 		}
-		sums[key] = sum
+		groups = append(groups, group)
 	}
-	if err = rows.Err(); err != nil {
+	err = rows.Err()
+	err = observe(q, query, values, start, 0, err)
+	if err != nil {
 		return nil, err
 	}
-	return sums, nil
+	return groups, nil
+}
+
+// Convert list of groups to map[key]value
+func groupMap[K comparable, V any](groups []Group[K, V]) map[K]V {
+	lookup := make(map[K]V, len(groups))
+	for _, group := range groups {
+		lookup[group.Key] = group.Value
+	}
+	return lookup
 }
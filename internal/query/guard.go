@@ -0,0 +1,64 @@
+package query
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/roidaradal/rdb/internal/condition"
+)
+
+var maxRowsAffected atomic.Int64 // default: 0 = no limit
+
+// Guards the number of rows affected by Update and Delete queries
+type rowsGuard struct {
+	maxRows uint
+}
+
+// Query with max number of rows affected
+type rowsGuarded interface {
+	maxRowsAffected() int
+}
+
+// Set global max number of rows affected by Update and Delete queries (0 = no limit);
+// guarded queries run in a transaction, which is rolled back if the limit is exceeded
+func SetMaxRowsAffected(limit uint) {
+	maxRowsAffected.Store(int64(limit))
+}
+
+// Set max number of rows affected by the query (overrides global limit, 0 = use global limit)
+func (g *rowsGuard) MaxRowsAffected(limit uint) {
+	g.maxRows = limit
+}
+
+// Get max number of rows affected: query limit if set, otherwise global limit
+func (g rowsGuard) maxRowsAffected() int {
+	if g.maxRows > 0 {
+		return int(g.maxRows)
+	}
+	return int(maxRowsAffected.Load())
+}
+
+// Get query's max number of rows affected (0 = no limit)
+func maxRowsOf(q Query) int {
+	if g, ok := q.(rowsGuarded); ok {
+		return g.maxRowsAffected()
+	}
+	return 0
+}
+
+// Check that number of rows affected is within query's limit
+func checkRowsAffected(q Query, rowsAffected int) error {
+	limit := maxRowsOf(q)
+	if limit > 0 && rowsAffected > limit {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyRows, rowsAffected, limit)
+	}
+	return nil
+}
+
+// List problem if condition matches all rows without the AllowFullTable opt-in
+func (q conditionQuery) fullTableProblems() []error {
+	if condition.IsMatchAll(q.condition) && !condition.IsFullTable(q.condition) {
+		return []error{fmt.Errorf("use AllowFullTable to update or delete all rows: %w", ErrFullTable)}
+	}
+	return []error{}
+}
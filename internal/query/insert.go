@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/roidaradal/fn/dict"
+	"github.com/roidaradal/fn/lang"
+	"github.com/roidaradal/fn/list"
 	"github.com/roidaradal/fn/str"
 )
 
@@ -18,7 +20,11 @@ type InsertRow struct {
 // InsertRows Query
 type InsertRows struct {
 	baseQuery
-	rows []dict.Object
+	rows      []dict.Object
+	maxParams int    // max placeholders per batch
+	maxBytes  int    // max estimated statement size per batch
+	returning string // RETURNING column, used by InsertIDs on PostgreSQL and SQLite
+	cache     *batchCache[InsertRows]
 }
 
 // Create new InsertRow Query
@@ -34,6 +40,9 @@ func NewInsertRows(table string) *InsertRows {
 	q := &InsertRows{}
 	q.baseQuery.initialize(table)
 	q.rows = make([]dict.Object, 0)
+	q.maxParams = DefaultMaxParams
+	q.maxBytes = DefaultMaxBytes
+	q.cache = &batchCache[InsertRows]{}
 	return q
 }
 
@@ -45,6 +54,14 @@ func (q *InsertRow) Row(row dict.Object) {
 // Set InsertRows Query's rows
 func (q *InsertRows) Rows(rows []dict.Object) {
 	q.rows = rows
+	q.cache.reset()
+}
+
+// Set InsertRows batch limits, used by ExecBatches and ExecBatchesTx: 0 = use default
+func (q *InsertRows) BatchLimits(maxParams, maxBytes int) {
+	q.maxParams = lang.Ternary(maxParams > 0, maxParams, DefaultMaxParams)
+	q.maxBytes = lang.Ternary(maxBytes > 0, maxBytes, DefaultMaxBytes)
+	q.cache.reset()
 }
 
 // Build InsertRow Query
@@ -94,9 +111,50 @@ func (q InsertRows) Build() (string, []any) {
 	placeholders := str.Repeat(numRows, placeholder, ", ")
 	query := "INSERT INTO %s (%s) VALUES %s"
 	query = fmt.Sprintf(query, q.table, columns, placeholders)
+	if q.returning != "" {
+		query = fmt.Sprintf("%s RETURNING %s", query, q.returning)
+	}
 	return query, values
 }
 
+// Validate InsertRow Query
+func (q InsertRow) Validate() error {
+	problems := q.baseQuery.problems()
+	if len(q.row) == 0 {
+		problems = append(problems, ErrMissingColumns)
+	}
+	return newValidationError(problems)
+}
+
+// Validate InsertRows Query
+func (q InsertRows) Validate() error {
+	problems := q.baseQuery.problems()
+	if len(q.rows) == 0 {
+		problems = append(problems, ErrMissingRows)
+		return newValidationError(problems)
+	}
+	row1 := q.rows[0]
+	if len(row1) == 0 {
+		problems = append(problems, fmt.Errorf("row 1: %w", ErrMissingColumns))
+	}
+	for i, row := range q.rows[1:] {
+		missing := list.Filter(dict.Keys(row1), func(column string) bool {
+			return dict.NoKey(row, column)
+		})
+		extra := list.Filter(dict.Keys(row), func(column string) bool {
+			return dict.NoKey(row1, column)
+		})
+		if len(missing) == 0 && len(extra) == 0 {
+			continue
+		}
+		slices.Sort(missing)
+		slices.Sort(extra)
+		err := fmt.Errorf("row %d: %w (missing: %v, extra: %v)", i+2, ErrMismatchedColumns, missing, extra)
+		problems = append(problems, err)
+	}
+	return newValidationError(problems)
+}
+
 // Join sorted column names
 func columnOrder(row dict.Object) string {
 	columns := dict.Keys(row)
@@ -43,6 +43,14 @@ func (q Lookup[T, K, V]) Build() (string, []any) {
 	return query, values
 }
 
+// Validate Lookup Query
+func (q Lookup[T, K, V]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, fieldProblems("key", q.keyColumn)...)
+	problems = append(problems, fieldProblems("value", q.valueColumn)...)
+	return newValidationError(problems)
+}
+
 // Execute Lookup Query and get map[K]V lookup
 func (q Lookup[T, K, V]) Lookup(dbc *sql.DB) (map[K]V, error) {
 	query, values, err := preReadCheck(q, dbc, q.reader)
@@ -51,7 +59,7 @@ func (q Lookup[T, K, V]) Lookup(dbc *sql.DB) (map[K]V, error) {
 	}
 
 	lookup := make(map[K]V)
-	err = readRows(dbc, query, values, q.reader, func(item *T) {
+	err = readRows(q, dbc, query, values, q.reader, func(item *T) {
 		key, err1 := getTypedColumnValue[K](item, q.typeName, q.keyColumn)
 		value, err2 := getTypedColumnValue[V](item, q.typeName, q.valueColumn)
 		if err1 != nil || err2 != nil {
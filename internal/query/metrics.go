@@ -0,0 +1,38 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/roidaradal/rdb/internal/metrics"
+)
+
+// Record query metrics, labeled by table and query type.
+// Returns the error wrapped as QueryError
+func observe(q Query, query string, values []any, start time.Time, rowsAffected int, err error) error {
+	metrics.ObserveQuery(queryLabels(q), start, rowsAffected, err)
+	return newQueryError(q, query, values, err)
+}
+
+// Get table and operation labels of query
+func queryLabels(q Query) metrics.Labels {
+	labels := metrics.Labels{Operation: operationOf(q)}
+	if tq, ok := q.(interface{ tableName() string }); ok {
+		labels.Table = tq.tableName()
+	}
+	return labels
+}
+
+// Get operation name from query type, without type parameters
+func operationOf(q Query) string {
+	t := reflect.TypeOf(q)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name, _, _ := strings.Cut(t.Name(), "[")
+	return name
+}
@@ -0,0 +1,117 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roidaradal/fn/dict"
+	"github.com/roidaradal/rdb/internal/condition"
+	"github.com/roidaradal/rdb/internal/dialect"
+	"github.com/roidaradal/rdb/internal/rdb"
+)
+
+type event struct {
+	ID        uint
+	Name      string
+	CreatedAt time.Time `col:"created_at"`
+}
+
+// Register event type and switch to PostgreSQL, until the test ends
+func setupPostgreSQL(t *testing.T) *event {
+	t.Helper()
+	rdb.Initialize()
+	e := &event{}
+	if err := rdb.AddType(e); err != nil {
+		t.Fatal(err)
+	}
+	dialect.Set(dialect.PostgreSQL)
+	t.Cleanup(func() { dialect.Set(dialect.MySQL) })
+	return e
+}
+
+// Build query in the current dialect's syntax, as executed
+func buildStatement(t *testing.T, q Query) string {
+	t.Helper()
+	query, _, err := buildCheck(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return query
+}
+
+func TestPostgreSQLDeleteLimit(t *testing.T) {
+	e := setupPostgreSQL(t)
+	q := NewDelete("events")
+	q.Where(condition.NewValue(&e.Name, "x", condition.Equal))
+	q.OrderAsc(rdb.GetColumnName(&e.ID))
+	q.Limit(10)
+	want := `DELETE FROM "events" WHERE ctid IN (SELECT ctid FROM "events" WHERE "Name" = $1 ORDER BY "ID" ASC LIMIT 10)`
+	if got := buildStatement(t, q); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestPostgreSQLInsertReturning(t *testing.T) {
+	e := setupPostgreSQL(t)
+	q := NewInsertRows("events")
+	q.Rows([]dict.Object{{"`Name`": "a"}, {"`Name`": "b"}})
+	batch := *q.Batches()[0]
+	batch.returning = rdb.GetColumnName(&e.ID)
+	want := `INSERT INTO "events" ("Name") VALUES ($1), ($2) RETURNING "ID"`
+	if got := buildStatement(t, batch); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+	if query, _ := q.Build(); query != "INSERT INTO `events` (`Name`) VALUES (?), (?)" {
+		t.Errorf("cached batch changed: %s", query)
+	}
+}
+
+func TestPostgreSQLTimeSeries(t *testing.T) {
+	e := setupPostgreSQL(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := NewTimeSeriesCount("events", &e.CreatedAt, Day)
+	q.Range(start, start.AddDate(0, 1, 0))
+	bucket := `TO_CHAR(DATE_TRUNC('day', "created_at"), 'YYYY-MM-DD HH24:MI:SS')`
+	want := `SELECT ` + bucket + `, COUNT(*) FROM "events" WHERE true AND "created_at" >= $1 AND "created_at" < $2 ` +
+		`GROUP BY ` + bucket + ` ORDER BY ` + bucket + ` ASC`
+	if got := buildStatement(t, q); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestPostgreSQLTimeSeriesLocation(t *testing.T) {
+	e := setupPostgreSQL(t)
+	manila, err := time.LoadLocation("Asia/Manila")
+	if err != nil {
+		t.Skip(err)
+	}
+	testCases := []struct {
+		location *time.Location
+		column   string
+	}{
+		{manila, `("created_at" AT TIME ZONE 'UTC' AT TIME ZONE 'Asia/Manila')`},
+		{time.FixedZone("", -(5*3600 + 30*60)), `("created_at" AT TIME ZONE 'UTC' AT TIME ZONE INTERVAL '-05:30')`},
+	}
+	for _, tc := range testCases {
+		q := NewTimeSeriesCount("events", &e.CreatedAt, Hour)
+		q.Location(tc.location)
+		bucket := `TO_CHAR(DATE_TRUNC('hour', ` + tc.column + `), 'YYYY-MM-DD HH24:MI:SS')`
+		want := `SELECT ` + bucket + `, COUNT(*) FROM "events" WHERE true GROUP BY ` + bucket + ` ORDER BY ` + bucket + ` ASC`
+		if got := buildStatement(t, q); got != want {
+			t.Errorf("got  %s\nwant %s", got, want)
+		}
+	}
+}
+
+func TestPostgreSQLRender(t *testing.T) {
+	e := setupPostgreSQL(t)
+	q := NewDelete("events")
+	q.Where(condition.NewMulti(condition.And,
+		condition.NewValue(&e.Name, "it's", condition.Equal),
+		condition.NewValue(&e.ID, 7, condition.Greater),
+	))
+	want := `DELETE FROM "events" WHERE ("Name" = 'it''s' AND "ID" > 7)`
+	if got := ToString(q); got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
@@ -5,11 +5,12 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/roidaradal/fn/dyn"
 	"github.com/roidaradal/fn/lang"
 	"github.com/roidaradal/fn/str"
 	"github.com/roidaradal/rdb/internal/condition"
+	"github.com/roidaradal/rdb/internal/dialect"
 	"github.com/roidaradal/rdb/internal/rdb"
 )
 
@@ -32,7 +33,9 @@ type conditionQuery struct {
 
 // Initialize BaseQuery
 func (q *baseQuery) initialize(table string) {
-	q.table = str.WrapBackticks(table)
+	if table != "" {
+		q.table = str.WrapBackticks(table)
+	}
 }
 
 // Initialize ConditionQuery, with required condition
@@ -47,35 +50,97 @@ func (q *conditionQuery) initializeOptional(table string) {
 	q.condition = condition.MatchAll{} // if condition not set later, defaults to match all condition
 }
 
+// Get table name, without backticks
+func (q baseQuery) tableName() string {
+	return strings.Trim(q.table, "`")
+}
+
 // Check if table is set
 func (q baseQuery) preBuildCheck() error {
-	return lang.Ternary(q.table == "", errEmptyTable, nil)
+	return lang.Ternary(q.table == "", ErrMissingTable, nil)
 }
 
-// Check if table is set, and build the condition
+// Check if table and condition are set, and build the condition
 func (q conditionQuery) preBuildCheck() (string, []any, error) {
 	err := q.baseQuery.preBuildCheck()
+	if q.condition == nil {
+		return "", []any{}, ErrMissingCondition
+	}
 	condition, values := q.condition.Build()
 	return condition, values, err
 }
 
+// List of BaseQuery problems
+func (q baseQuery) problems() []error {
+	problems := make([]error, 0)
+	if q.table == "" {
+		problems = append(problems, ErrMissingTable)
+	}
+	return problems
+}
+
+// List of ConditionQuery problems
+func (q conditionQuery) problems() []error {
+	problems := q.baseQuery.problems()
+	if q.condition == nil {
+		problems = append(problems, ErrMissingCondition)
+	}
+	return problems
+}
+
+// List problems of given column list
+func columnProblems(columns []string) []error {
+	problems := make([]error, 0)
+	if len(columns) == 0 {
+		problems = append(problems, ErrMissingColumns)
+	}
+	for i, column := range columns {
+		if column == "" {
+			problems = append(problems, fmt.Errorf("column #%d: %w", i+1, ErrMissingColumns))
+		}
+	}
+	return problems
+}
+
+// List problems of given field's column
+func fieldProblems(label, column string) []error {
+	if column == "" {
+		return []error{fmt.Errorf("%s field: %w", label, ErrUnknownField)}
+	}
+	return []error{}
+}
+
 // Set Query condition
 func (q *conditionQuery) Where(queryCondition condition.Condition) {
 	q.condition = queryCondition
 }
 
-// Before query, check the db connection and build the query
+// Before query, check the db connection, validate and build the query
 func preQueryCheck(q Query, dbc *sql.DB) (string, []any, error) {
-	var err error = nil
-	query, values := q.Build()
-	if dbc == nil {
-		err = errNoDBConnection
-	} else if query == "" {
-		err = errEmptyQuery
+	query, values, err := buildCheck(q)
+	if err == nil && dbc == nil {
+		err = ErrNoDBConnection
+	}
+	if err != nil {
+		err = observe(q, query, values, time.Now(), 0, err)
 	}
 	return query, values, err
 }
 
+// Validate the query (if it supports validation), then build it in the current dialect's syntax
+func buildCheck(q Query) (string, []any, error) {
+	if v, ok := q.(validator); ok {
+		if err := v.Validate(); err != nil {
+			return "", []any{}, err
+		}
+	}
+	query, values := q.Build()
+	if query == "" {
+		return query, values, ErrEmptyQuery
+	}
+	return dialect.Current().Rebind(query), values, nil
+}
+
 // Before SELECT query, check db connection, reader, and build the query
 func preReadCheck[T any](q Query, dbc *sql.DB, reader rdb.RowReader[T]) (string, []any, error) {
 	query, values, err := preQueryCheck(q, dbc)
@@ -83,26 +148,20 @@ func preReadCheck[T any](q Query, dbc *sql.DB, reader rdb.RowReader[T]) (string,
 		return query, values, err
 	}
 	if reader == nil {
-		err = errNoReader
+		err = newQueryError(q, query, values, ErrNoReader)
 	}
 	return query, values, err
 }
 
-// Build full query string
+// Build full query string, with values rendered in the current dialect
 func ToString(q Query) string {
-	query, rawValues := q.Build()
-	values := make([]any, len(rawValues))
-	for i, value := range rawValues {
-		typeName := fmt.Sprintf("%T", value)
-		if strings.HasPrefix(typeName, "*") {
-			values[i] = dyn.Deref(value)
-		} else {
-			values[i] = value
-		}
-	}
-	// Replace all placeholders with %v format for values
-	query = strings.Replace(query, "?", "%v", -1)
-	return fmt.Sprintf(query, values...)
+	return ToDialectString(q, dialect.Current())
+}
+
+// Build full query string, with values rendered in the given dialect
+func ToDialectString(q Query, d dialect.Dialect) string {
+	query, values := q.Build()
+	return d.Render(query, values)
 }
 
 // Returns empty query and empty list of values
@@ -110,10 +169,21 @@ func emptyQueryValues() (string, []any) {
 	return "", []any{}
 }
 
+// Read one row from query
+func readRow[T any](q Query, dbc *sql.DB, query string, values []any, reader rdb.RowReader[T]) (*T, error) {
+	start := time.Now()
+	row := dbc.QueryRow(query, values...)
+	item, err := reader(row)
+	err = observe(q, query, values, start, 0, err)
+	return item, err
+}
+
 // Read rows from query
-func readRows[T any](dbc *sql.DB, query string, values []any, reader rdb.RowReader[T], task func(*T)) error {
+func readRows[T any](q Query, dbc *sql.DB, query string, values []any, reader rdb.RowReader[T], task func(*T)) error {
+	start := time.Now()
 	rows, err := dbc.Query(query, values...)
 	if err != nil {
+		err = observe(q, query, values, start, 0, err)
 		return err
 	}
 	defer rows.Close()
@@ -125,9 +195,7 @@ func readRows[T any](dbc *sql.DB, query string, values []any, reader rdb.RowRead
 		}
 		task(item)
 	}
-	if err = rows.Err(); err != nil {
-		return err
-	}
-
-	return nil
+	err = rows.Err()
+	err = observe(q, query, values, start, 0, err)
+	return err
 }
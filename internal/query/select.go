@@ -91,6 +91,25 @@ func (q *SelectRows[T]) OrderDesc(column string) {
 	q.order = fmt.Sprintf("%s DESC", column)
 }
 
+// Add SelectRows column order (ascending), after existing order
+func (q *SelectRows[T]) ThenAsc(column string) {
+	q.addOrder(fmt.Sprintf("%s ASC", column))
+}
+
+// Add SelectRows column order (descending), after existing order
+func (q *SelectRows[T]) ThenDesc(column string) {
+	q.addOrder(fmt.Sprintf("%s DESC", column))
+}
+
+// Append to SelectRows order
+func (q *SelectRows[T]) addOrder(order string) {
+	if q.order == "" {
+		q.order = order
+	} else {
+		q.order = fmt.Sprintf("%s, %s", q.order, order)
+	}
+}
+
 // Build SelectRow Query
 func (q SelectRow[T]) Build() (string, []any) {
 	condition, values, err := q.conditionQuery.preBuildCheck()
@@ -121,14 +140,27 @@ func (q SelectRows[T]) Build() (string, []any) {
 	return query, values
 }
 
+// Validate SelectRow Query
+func (q SelectRow[T]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, columnProblems(q.columns)...)
+	return newValidationError(problems)
+}
+
+// Validate SelectRows Query
+func (q SelectRows[T]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, columnProblems(q.columns)...)
+	return newValidationError(problems)
+}
+
 // Execute SelectRow Query and get the row object
 func (q SelectRow[T]) QueryRow(dbc *sql.DB) (*T, error) {
 	query, values, err := preReadCheck(q, dbc, q.reader)
 	if err != nil {
 		return nil, err
 	}
-	row := dbc.QueryRow(query, values...)
-	return q.reader(row)
+	return readRow(q, dbc, query, values, q.reader)
 }
 
 // Execute SelectRows Query and get list of objects
@@ -139,7 +171,7 @@ func (q SelectRows[T]) Query(dbc *sql.DB) ([]*T, error) {
 	}
 
 	items := make([]*T, 0)
-	err = readRows(dbc, query, values, q.reader, func(item *T) {
+	err = readRows(q, dbc, query, values, q.reader, func(item *T) {
 		items = append(items, item)
 	})
 	if err != nil {
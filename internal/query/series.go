@@ -0,0 +1,374 @@
+package query
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/roidaradal/fn/lang"
+	"github.com/roidaradal/rdb/internal/dialect"
+	"github.com/roidaradal/rdb/internal/rdb"
+)
+
+// Time bucket size
+type TimeBucket int
+
+const (
+	Hour TimeBucket = iota
+	Day
+	Week // weeks start on Monday
+	Month
+)
+
+// Layout of bucket start, as rendered by the bucket expression
+const bucketLayout string = "2006-01-02 15:04:05"
+
+// Bucket start time and aggregate value
+type TimePoint[V Number] struct {
+	Time  time.Time
+	Value V
+}
+
+// TimeSeries Query: COUNT or SUM per time bucket, ordered by time
+type TimeSeries[V Number] struct {
+	conditionQuery
+	timeColumn  string
+	bucket      TimeBucket
+	aggregate   string // COUNT(*) or SUM(column)
+	sumColumn   string
+	groupColumn string // set by GroupSeries
+	grouped     bool
+	start       time.Time
+	end         time.Time
+	location    *time.Location
+}
+
+// Create new TimeSeries Query that counts rows per bucket of time field
+func NewTimeSeriesCount(table string, timeFieldRef any, bucket TimeBucket) *TimeSeries[int] {
+	q := newTimeSeries[int](table, timeFieldRef, bucket)
+	q.aggregate = "COUNT(*)"
+	return q
+}
+
+// Create new TimeSeries Query that sums field per bucket of time field
+func NewTimeSeriesSum[V Number](table string, timeFieldRef any, bucket TimeBucket, sumFieldRef *V) *TimeSeries[V] {
+	q := newTimeSeries[V](table, timeFieldRef, bucket)
+	q.sumColumn = rdb.GetColumnName(sumFieldRef)
+	q.aggregate = fmt.Sprintf("SUM(%s)", q.sumColumn)
+	return q
+}
+
+// Create new TimeSeries Query
+func newTimeSeries[V Number](table string, timeFieldRef any, bucket TimeBucket) *TimeSeries[V] {
+	q := &TimeSeries[V]{}
+	q.initializeOptional(table)
+	q.timeColumn = rdb.GetColumnName(timeFieldRef)
+	q.bucket = bucket
+	q.location = time.UTC
+	return q
+}
+
+// Set time range [start, end): rows outside the range are excluded,
+// and empty buckets are filled from start to end
+func (q *TimeSeries[V]) Range(start, end time.Time) {
+	q.start = start
+	q.end = end
+}
+
+// Set location of buckets (default: UTC): time values, stored in UTC, are converted
+// to the location before truncation, so buckets start at the location's midnight
+func (q *TimeSeries[V]) Location(location *time.Location) {
+	if location != nil {
+		q.location = location
+	}
+}
+
+// Build TimeSeries Query
+func (q TimeSeries[V]) Build() (string, []any) {
+	condition, values, err := q.conditionQuery.preBuildCheck()
+	bucket := q.bucketExpression()
+	if err != nil || q.timeColumn == "" || q.aggregate == "" || bucket == "" {
+		return emptyQueryValues()
+	}
+	if !q.start.IsZero() && !q.end.IsZero() {
+		condition = fmt.Sprintf("%s AND %s >= ? AND %s < ?", condition, q.timeColumn, q.timeColumn)
+		values = append(values, q.start, q.end)
+	}
+	groups := bucket
+	if q.groupColumn != "" {
+		groups = fmt.Sprintf("%s, %s", bucket, q.groupColumn)
+	}
+	query := "SELECT %s, %s FROM %s WHERE %s GROUP BY %s ORDER BY %s ASC"
+	query = fmt.Sprintf(query, groups, q.aggregate, q.table, condition, groups, bucket)
+	return query, values
+}
+
+// Validate TimeSeries Query
+func (q TimeSeries[V]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, fieldProblems("time", q.timeColumn)...)
+	if q.aggregate != "COUNT(*)" {
+		problems = append(problems, fieldProblems("sum", q.sumColumn)...)
+	}
+	if q.grouped {
+		problems = append(problems, fieldProblems("group", q.groupColumn)...)
+	}
+	if q.bucketExpression() == "" {
+		problems = append(problems, ErrInvalidBucket)
+	}
+	if q.start.IsZero() != q.end.IsZero() || q.end.Before(q.start) {
+		problems = append(problems, fmt.Errorf("time range: %w", ErrInvalidBucket))
+	}
+	return newValidationError(problems)
+}
+
+// Execute TimeSeries Query and get list of (bucket, value), ordered by time, with empty buckets filled
+func (q TimeSeries[V]) Series(dbc *sql.DB) ([]TimePoint[V], error) {
+	query, values, err := preQueryCheck(q, dbc)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	rows, err := dbc.Query(query, values...)
+	if err != nil {
+		err = observe(q, query, values, start, 0, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	points := make(map[time.Time]V)
+	for rows.Next() {
+		var bucket string
+		var value V
+		err = rows.Scan(&bucket, &value)
+		if err != nil {
+			continue
+		}
+		if t, err := time.ParseInLocation(bucketLayout, bucket, q.location); err == nil {
+			points[t] = value
+		}
+	}
+	err = rows.Err()
+	err = observe(q, query, values, start, 0, err)
+	if err != nil {
+		return nil, err
+	}
+	return q.fill(points), nil
+}
+
+// Execute TimeSeries Query grouped by field and get map[group] => list of (bucket, value),
+// each group has the same buckets, with empty buckets filled
+func GroupSeries[K comparable, V Number](q *TimeSeries[V], dbc *sql.DB, groupFieldRef *K) (map[K][]TimePoint[V], error) {
+	// Note: Cannot be method as generics are not supported in methods
+	gq := *q
+	gq.grouped = true
+	gq.groupColumn = rdb.GetColumnName(groupFieldRef)
+	query, values, err := preQueryCheck(gq, dbc)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	rows, err := dbc.Query(query, values...)
+	if err != nil {
+		err = observe(gq, query, values, start, 0, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	groupPoints := make(map[K]map[time.Time]V)
+	allPoints := make(map[time.Time]V) // all buckets found, used for fill range
+	for rows.Next() {
+		var bucket string
+		var key K
+		var value V
+		err = rows.Scan(&bucket, &key, &value)
+		if err != nil {
+			continue
+		}
+		t, err := time.ParseInLocation(bucketLayout, bucket, gq.location)
+		if err != nil {
+			continue
+		}
+		if _, ok := groupPoints[key]; !ok {
+			groupPoints[key] = make(map[time.Time]V)
+		}
+		groupPoints[key][t] = value
+		allPoints[t] = value
+	}
+	err = rows.Err()
+	err = observe(gq, query, values, start, 0, err)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := gq.bounds(allPoints)
+	series := make(map[K][]TimePoint[V], len(groupPoints))
+	for key, points := range groupPoints {
+		series[key] = gq.fillBetween(points, first, last)
+	}
+	return series, nil
+}
+
+// Get dialect-specific expression that truncates time column to bucket start
+func (q TimeSeries[V]) bucketExpression() string {
+	column := q.localColumn()
+	switch dialect.Current() {
+	case dialect.PostgreSQL:
+		unit := map[TimeBucket]string{Hour: "hour", Day: "day", Week: "week", Month: "month"}[q.bucket]
+		if unit == "" {
+			return ""
+		}
+		return fmt.Sprintf("TO_CHAR(DATE_TRUNC('%s', %s), 'YYYY-MM-DD HH24:MI:SS')", unit, column)
+	case dialect.SQLite:
+		switch q.bucket {
+		case Hour:
+			return fmt.Sprintf("STRFTIME('%%Y-%%m-%%d %%H:00:00', %s)", column)
+		case Day:
+			return fmt.Sprintf("STRFTIME('%%Y-%%m-%%d 00:00:00', %s)", column)
+		case Week:
+			return fmt.Sprintf("STRFTIME('%%Y-%%m-%%d 00:00:00', %s, 'weekday 0', '-6 days')", column)
+		case Month:
+			return fmt.Sprintf("STRFTIME('%%Y-%%m-01 00:00:00', %s)", column)
+		}
+	default:
+		switch q.bucket {
+		case Hour:
+			return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d %%H:00:00')", column)
+		case Day:
+			return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d 00:00:00')", column)
+		case Week:
+			return fmt.Sprintf("DATE_FORMAT(DATE_SUB(%s, INTERVAL WEEKDAY(%s) DAY), '%%Y-%%m-%%d 00:00:00')", column, column)
+		case Month:
+			return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-01 00:00:00')", column)
+		}
+	}
+	return ""
+}
+
+// Get dialect-specific expression that converts time column from UTC to the query location;
+// MySQL named zones require the time zone tables, SQLite uses the location's offset at range start (or now)
+func (q TimeSeries[V]) localColumn() string {
+	column := q.timeColumn
+	if q.location == time.UTC || column == "" {
+		return column
+	}
+	d := dialect.Current()
+	at := lang.Ternary(q.start.IsZero(), time.Now(), q.start)
+	_, offset := at.In(q.location).Zone()
+	if d == dialect.SQLite {
+		// no time zone support, use fixed offset
+		return fmt.Sprintf("DATETIME(%s, '%+d minutes')", column, offset/60)
+	}
+	name := q.location.String()
+	zone := d.Literal(name)
+	if !isNamedZone(name, at, offset) {
+		// not a named time zone (e.g. fixed zone), use fixed offset
+		sign := lang.Ternary(offset < 0, "-", "+")
+		offset = lang.Ternary(offset < 0, -offset, offset)
+		zone = fmt.Sprintf("'%s%02d:%02d'", sign, offset/3600, offset%3600/60)
+		if d == dialect.PostgreSQL {
+			zone = "INTERVAL " + zone
+		}
+	}
+	if d == dialect.PostgreSQL {
+		return fmt.Sprintf("(%s AT TIME ZONE 'UTC' AT TIME ZONE %s)", column, zone)
+	}
+	return fmt.Sprintf("CONVERT_TZ(%s, '+00:00', %s)", column, zone)
+}
+
+// Check if location name is a time zone name known by the database, with the same offset at given time;
+// fixed zones may have empty or arbitrary names
+func isNamedZone(name string, at time.Time, offset int) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return false
+	}
+	_, namedOffset := at.In(location).Zone()
+	return namedOffset == offset
+}
+
+// Fill empty buckets of the query range, or between the first and last bucket found
+func (q TimeSeries[V]) fill(points map[time.Time]V) []TimePoint[V] {
+	first, last := q.bounds(points)
+	return q.fillBetween(points, first, last)
+}
+
+// Get first and last bucket: from the query range if set, otherwise from the buckets found
+func (q TimeSeries[V]) bounds(points map[time.Time]V) (time.Time, time.Time) {
+	if !q.start.IsZero() && !q.end.IsZero() {
+		last := q.truncate(q.end)
+		if last.Equal(q.end.In(q.location)) {
+			last = q.previous(last) // end is exclusive
+		}
+		return q.truncate(q.start), last
+	}
+	var first, last time.Time
+	for t := range points {
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if last.IsZero() || t.After(last) {
+			last = t
+		}
+	}
+	return first, last
+}
+
+// Create list of buckets from first to last (inclusive), using found values or zero
+func (q TimeSeries[V]) fillBetween(points map[time.Time]V, first, last time.Time) []TimePoint[V] {
+	series := make([]TimePoint[V], 0)
+	if first.IsZero() || last.IsZero() {
+		return series
+	}
+	for t := first; !t.After(last); t = q.next(t) {
+		series = append(series, TimePoint[V]{Time: t, Value: points[t]})
+	}
+	return series
+}
+
+// Truncate time to start of its bucket, in the query location
+func (q TimeSeries[V]) truncate(t time.Time) time.Time {
+	t = t.In(q.location)
+	year, month, day := t.Date()
+	switch q.bucket {
+	case Hour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, q.location)
+	case Week:
+		offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, q.location)
+	case Month:
+		return time.Date(year, month, 1, 0, 0, 0, 0, q.location)
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, q.location)
+	}
+}
+
+// Get start of next bucket
+func (q TimeSeries[V]) next(t time.Time) time.Time {
+	return q.step(t, 1)
+}
+
+// Get start of previous bucket
+func (q TimeSeries[V]) previous(t time.Time) time.Time {
+	return q.step(t, -1)
+}
+
+// Move bucket start by count buckets
+func (q TimeSeries[V]) step(t time.Time, count int) time.Time {
+	year, month, day := t.Date()
+	switch q.bucket {
+	case Hour:
+		return time.Date(year, month, day, t.Hour()+count, 0, 0, 0, q.location)
+	case Week:
+		return time.Date(year, month, day+7*count, 0, 0, 0, 0, q.location)
+	case Month:
+		return time.Date(year, month+time.Month(count), 1, 0, 0, 0, 0, q.location)
+	default:
+		return time.Date(year, month, day+count, 0, 0, 0, 0, q.location)
+	}
+}
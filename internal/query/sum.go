@@ -45,12 +45,18 @@ func (q SumQuery[T]) Build() (string, []any) {
 	return query, values
 }
 
+// Validate Sum Query
+func (q SumQuery[T]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, columnProblems(q.columns)...)
+	return newValidationError(problems)
+}
+
 // Execute Sum Query and get sum object
 func (q SumQuery[T]) Sum(dbc *sql.DB) (*T, error) {
 	query, values, err := preReadCheck(q, dbc, q.reader)
 	if err != nil {
 		return nil, err
 	}
-	row := dbc.QueryRow(query, values...)
-	return q.reader(row)
+	return readRow(q, dbc, query, values, q.reader)
 }
@@ -104,6 +104,26 @@ func (q TopValue[T, V]) Build() (string, []any) {
 	return query, values
 }
 
+// Validate TopRow Query
+func (q TopRow[T]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, columnProblems(q.columns)...)
+	if q.order == "" {
+		problems = append(problems, ErrMissingOrder)
+	}
+	return newValidationError(problems)
+}
+
+// Validate TopValue Query
+func (q TopValue[T, V]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, fieldProblems("value", q.columnName)...)
+	if q.order == "" {
+		problems = append(problems, ErrMissingOrder)
+	}
+	return newValidationError(problems)
+}
+
 // Execute TopRow Query and get top row object
 func (q TopRow[T]) QueryRow(dbc *sql.DB) (*T, error) {
 	q.limit = 1 // override limit = 1
@@ -111,8 +131,7 @@ func (q TopRow[T]) QueryRow(dbc *sql.DB) (*T, error) {
 	if err != nil {
 		return nil, err
 	}
-	row := dbc.QueryRow(query, values...)
-	return q.reader(row)
+	return readRow(q, dbc, query, values, q.reader)
 }
 
 // Execute TopRow Query and get top N row objects
@@ -123,7 +142,7 @@ func (q TopRow[T]) QueryRows(dbc *sql.DB) ([]*T, error) {
 	}
 
 	items := make([]*T, 0)
-	err = readRows(dbc, query, values, q.reader, func(item *T) {
+	err = readRows(q, dbc, query, values, q.reader, func(item *T) {
 		items = append(items, item)
 	})
 	if err != nil {
@@ -141,8 +160,7 @@ func (q TopValue[T, V]) QueryValue(dbc *sql.DB) (V, error) {
 	if err != nil {
 		return v, err
 	}
-	row := dbc.QueryRow(query, values...)
-	item, err := q.reader(row)
+	item, err := readRow(q, dbc, query, values, q.reader)
 	if err != nil {
 		return v, err
 	}
@@ -157,7 +175,7 @@ func (q TopValue[T, V]) QueryValues(dbc *sql.DB) ([]V, error) {
 	}
 
 	topValues := make([]V, 0)
-	err = readRows(dbc, query, values, q.reader, func(item *T) {
+	err = readRows(q, dbc, query, values, q.reader, func(item *T) {
 		value, err := getTypedColumnValue[V](item, q.typeName, q.columnName)
 		if err != nil {
 			return
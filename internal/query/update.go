@@ -19,9 +19,12 @@ func (f FieldUpdate) Tuple() (any, any) {
 // Update Query
 type Update[T any] struct {
 	conditionQuery
+	rowsGuard
 	typeName string
 	updates  []*rdb.Value
+	exprs    []updateExpression // column = expression updates
 	limit    uint
+	invalid  []error // problems found when adding updates
 }
 
 // Create new Update Query
@@ -31,13 +34,31 @@ func NewUpdate[T any](table string) *Update[T] {
 	q.initializeRequired(table)
 	q.typeName = dyn.TypeOf(t)
 	q.updates = make([]*rdb.Value, 0)
+	q.exprs = make([]updateExpression, 0)
+	q.invalid = make([]error, 0)
 	return q
 }
 
 // Add field=value update to Update Query
 func AddUpdate[T, V any](q *Update[T], fieldRef *V, value V) {
 	// Note: Cannot be method as generics are not supported in methods
-	q.updates = append(q.updates, rdb.KeyValue(fieldRef, value))
+	pair := rdb.KeyUpdate(fieldRef, value)
+	if pair == nil {
+		err := fmt.Errorf("update #%d: %w", len(q.updates)+1, ErrUnknownField)
+		q.invalid = append(q.invalid, err)
+	}
+	q.updates = append(q.updates, pair)
+}
+
+// Add field=NULL update to Update Query
+func AddNullUpdate[T any](q *Update[T], fieldRef any) {
+	// Note: Cannot be method as generics are not supported in methods
+	pair := rdb.KeyNull(fieldRef)
+	if pair == nil {
+		err := fmt.Errorf("update #%d: %w", len(q.updates)+1, ErrUnknownField)
+		q.invalid = append(q.invalid, err)
+	}
+	q.updates = append(q.updates, pair)
 }
 
 // Set limit for Update Query
@@ -47,7 +68,12 @@ func (q *Update[T]) Limit(limit uint) {
 
 // Add column=value update to Update Query
 func (q *Update[T]) Update(fieldName string, value any) {
-	q.updates = append(q.updates, rdb.ColumnValue(q.typeName, fieldName, value))
+	pair := rdb.ColumnUpdate(q.typeName, fieldName, value)
+	if pair == nil {
+		err := fmt.Errorf("update %s.%s: %w", q.typeName, fieldName, ErrUnknownField)
+		q.invalid = append(q.invalid, err)
+	}
+	q.updates = append(q.updates, pair)
 }
 
 // Add list of column=value updates to Update Query
@@ -60,13 +86,13 @@ func (q *Update[T]) Updates(updates FieldUpdates) {
 
 // Build Update Query
 func (q Update[T]) Build() (string, []any) {
-	numUpdates := len(q.updates)
+	numUpdates := len(q.updates) + len(q.exprs)
 	condition, conditionValues, err := q.conditionQuery.preBuildCheck()
-	if err != nil || numUpdates == 0 {
+	if err != nil || numUpdates == 0 || len(q.invalid) > 0 {
 		return emptyQueryValues()
 	}
 	values := make([]any, 0, numUpdates+len(conditionValues))
-	updates := make([]string, numUpdates)
+	updates := make([]string, len(q.updates), numUpdates)
 	for i, pair := range q.updates {
 		if pair == nil {
 			// if key-value pair is nil, return empty query
@@ -80,6 +106,10 @@ func (q Update[T]) Build() (string, []any) {
 		updates[i] = fmt.Sprintf("%s = ?", column)
 		values = append(values, value)
 	}
+	for _, expr := range q.exprs {
+		updates = append(updates, fmt.Sprintf("%s = %s", expr.column, expr.expression))
+		values = append(values, expr.values...)
+	}
 	values = append(values, conditionValues...)
 	update := strings.Join(updates, ", ")
 	query := "UPDATE %s SET %s WHERE %s"
@@ -89,3 +119,14 @@ func (q Update[T]) Build() (string, []any) {
 	}
 	return query, values
 }
+
+// Validate Update Query
+func (q Update[T]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, q.conditionQuery.fullTableProblems()...)
+	if len(q.updates) == 0 && len(q.exprs) == 0 {
+		problems = append(problems, fmt.Errorf("no updates: %w", ErrMissingColumns))
+	}
+	problems = append(problems, q.invalid...)
+	return newValidationError(problems)
+}
@@ -38,6 +38,13 @@ func (q Value[T, V]) Build() (string, []any) {
 	return query, values
 }
 
+// Validate Value Query
+func (q Value[T, V]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, fieldProblems("value", q.columnName)...)
+	return newValidationError(problems)
+}
+
 // Execute Value Query and get column value
 func (q Value[T, V]) QueryValue(dbc *sql.DB) (V, error) {
 	var v V
@@ -45,8 +52,7 @@ func (q Value[T, V]) QueryValue(dbc *sql.DB) (V, error) {
 	if err != nil {
 		return v, err
 	}
-	row := dbc.QueryRow(query, values...)
-	item, err := q.reader(row)
+	item, err := readRow(q, dbc, query, values, q.reader)
 	if err != nil {
 		return v, err
 	}
@@ -59,11 +65,11 @@ func getTypedColumnValue[V any](structRef any, typeName, columnName string) (V,
 	var v V
 	value, ok := rdb.GetStructColumnValue(structRef, typeName, columnName)
 	if !ok {
-		return v, errNotFoundField
+		return v, ErrNotFoundField
 	}
 	v, ok = value.(V)
 	if !ok {
-		return v, errFailedTypeAssertion
+		return v, ErrFailedTypeAssertion
 	}
 	return v, nil
 }
@@ -0,0 +1,237 @@
+package query
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/roidaradal/fn/list"
+	"github.com/roidaradal/rdb/internal/rdb"
+)
+
+// Window Query, where T = source row type, R = result type that embeds T plus the window value fields;
+// all window functions share the same PARTITION BY and ORDER BY
+type Window[T, R any] struct {
+	conditionQuery
+	columns    []string       // source row columns
+	functions  []windowColumn // window functions and their aliases (result columns)
+	partitions []string
+	order      []string
+	top        string // alias used for top-N filter
+	topLimit   uint
+	invalid    []error // problems found when adding columns
+}
+
+// Window function and its alias (result column)
+type windowColumn struct {
+	function string // e.g. ROW_NUMBER(), SUM(`amount`)
+	frame    string // optional frame clause
+	alias    string
+}
+
+// Create new Window Query
+func NewWindow[T, R any](table string) *Window[T, R] {
+	var t T
+	q := &Window[T, R]{}
+	q.initializeOptional(table)
+	q.columns = rdb.ColumnsOf(t)
+	q.functions = make([]windowColumn, 0)
+	q.partitions = make([]string, 0)
+	q.order = make([]string, 0)
+	q.invalid = make([]error, 0)
+	return q
+}
+
+// Set window partition fields
+func (q *Window[T, R]) PartitionBy(fieldRefs ...any) {
+	q.partitions = make([]string, 0, len(fieldRefs))
+	for _, fieldRef := range fieldRefs {
+		column := rdb.GetColumnName(fieldRef)
+		if column == "" {
+			q.addProblem("partition", ErrUnknownField)
+			continue
+		}
+		q.partitions = append(q.partitions, column)
+	}
+}
+
+// Set window order by field (ascending)
+func (q *Window[T, R]) OrderAsc(fieldRef any) {
+	q.order = make([]string, 0)
+	q.ThenAsc(fieldRef)
+}
+
+// Set window order by field (descending)
+func (q *Window[T, R]) OrderDesc(fieldRef any) {
+	q.order = make([]string, 0)
+	q.ThenDesc(fieldRef)
+}
+
+// Add window order by field (ascending), after existing order
+func (q *Window[T, R]) ThenAsc(fieldRef any) {
+	q.addOrder(fieldRef, "ASC")
+}
+
+// Add window order by field (descending), after existing order
+func (q *Window[T, R]) ThenDesc(fieldRef any) {
+	q.addOrder(fieldRef, "DESC")
+}
+
+// Add ROW_NUMBER() into result field
+func (q *Window[T, R]) RowNumber(resultFieldRef any) {
+	q.addFunction("row number", "ROW_NUMBER()", "", resultFieldRef)
+}
+
+// Add RANK() into result field: ties have the same rank, with gaps after ties
+func (q *Window[T, R]) Rank(resultFieldRef any) {
+	q.addFunction("rank", "RANK()", "", resultFieldRef)
+}
+
+// Add DENSE_RANK() into result field: ties have the same rank, without gaps
+func (q *Window[T, R]) DenseRank(resultFieldRef any) {
+	q.addFunction("dense rank", "DENSE_RANK()", "", resultFieldRef)
+}
+
+// Add running SUM(field) into result field, from the partition start up to the current row
+func (q *Window[T, R]) RunningSum(fieldRef, resultFieldRef any) {
+	column := q.sourceColumn("running sum", fieldRef)
+	function := fmt.Sprintf("SUM(%s)", column)
+	q.addFunction("running sum", function, "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW", resultFieldRef)
+}
+
+// Add LAG(field, offset) into result field: value from offset rows before,
+// NULL if there is no such row (use pointer or nullzero result field)
+func (q *Window[T, R]) Lag(fieldRef, resultFieldRef any, offset uint) {
+	column := q.sourceColumn("lag", fieldRef)
+	function := fmt.Sprintf("LAG(%s, %d)", column, max(1, offset))
+	q.addFunction("lag", function, "", resultFieldRef)
+}
+
+// Add LEAD(field, offset) into result field: value from offset rows after,
+// NULL if there is no such row (use pointer or nullzero result field)
+func (q *Window[T, R]) Lead(fieldRef, resultFieldRef any, offset uint) {
+	column := q.sourceColumn("lead", fieldRef)
+	function := fmt.Sprintf("LEAD(%s, %d)", column, max(1, offset))
+	q.addFunction("lead", function, "", resultFieldRef)
+}
+
+// Only keep rows whose window result field is at most limit (e.g. top-N per partition using RowNumber)
+func (q *Window[T, R]) Top(resultFieldRef any, limit uint) {
+	q.top = rdb.GetColumnName(resultFieldRef)
+	if q.top == "" {
+		q.addProblem("top", ErrUnknownField)
+	}
+	q.topLimit = max(1, limit)
+}
+
+// Get source column of field reference
+func (q *Window[T, R]) sourceColumn(label string, fieldRef any) string {
+	column := rdb.GetColumnName(fieldRef)
+	if column == "" {
+		q.addProblem(label, ErrUnknownField)
+	}
+	return column
+}
+
+// Add window function, aliased as result field's column
+func (q *Window[T, R]) addFunction(label, function, frame string, resultFieldRef any) {
+	alias := rdb.GetColumnName(resultFieldRef)
+	if alias == "" {
+		q.addProblem(label+" result", ErrUnknownField)
+	}
+	q.functions = append(q.functions, windowColumn{function, frame, alias})
+}
+
+// Append field to window order
+func (q *Window[T, R]) addOrder(fieldRef any, direction string) {
+	column := rdb.GetColumnName(fieldRef)
+	if column == "" {
+		q.addProblem("order", ErrUnknownField)
+		return
+	}
+	q.order = append(q.order, fmt.Sprintf("%s %s", column, direction))
+}
+
+// Record problem found when adding columns
+func (q *Window[T, R]) addProblem(label string, err error) {
+	q.invalid = append(q.invalid, fmt.Errorf("%s: %w", label, err))
+}
+
+// Get result columns: source row columns, then window aliases
+func (q Window[T, R]) resultColumns() []string {
+	aliases := list.Map(q.functions, func(w windowColumn) string {
+		return w.alias
+	})
+	return append(append([]string{}, q.columns...), aliases...)
+}
+
+// Build window specification: OVER (PARTITION BY ... ORDER BY ... frame)
+func (q Window[T, R]) over(frame string) string {
+	parts := make([]string, 0, 3)
+	if len(q.partitions) > 0 {
+		parts = append(parts, "PARTITION BY "+strings.Join(q.partitions, ", "))
+	}
+	if len(q.order) > 0 {
+		parts = append(parts, "ORDER BY "+strings.Join(q.order, ", "))
+	}
+	if frame != "" {
+		parts = append(parts, frame)
+	}
+	return fmt.Sprintf("OVER (%s)", strings.Join(parts, " "))
+}
+
+// Build Window Query
+func (q Window[T, R]) Build() (string, []any) {
+	condition, values, err := q.conditionQuery.preBuildCheck()
+	if err != nil || len(q.columns) == 0 || len(q.functions) == 0 || len(q.invalid) > 0 {
+		return emptyQueryValues()
+	}
+	functions := list.Map(q.functions, func(w windowColumn) string {
+		return fmt.Sprintf("%s %s AS %s", w.function, q.over(w.frame), w.alias)
+	})
+	selected := strings.Join(append(append([]string{}, q.columns...), functions...), ", ")
+	query := "SELECT %s FROM %s WHERE %s"
+	query = fmt.Sprintf(query, selected, q.table, condition)
+	if q.top != "" {
+		// Window values cannot be used in WHERE, filter using a derived table
+		columns := strings.Join(q.resultColumns(), ", ")
+		query = fmt.Sprintf("SELECT %s FROM (%s) AS window_rows WHERE %s <= %d", columns, query, q.top, q.topLimit)
+	}
+	order := append(append([]string{}, q.partitions...), q.order...)
+	if len(order) > 0 {
+		query = fmt.Sprintf("%s ORDER BY %s", query, strings.Join(order, ", "))
+	}
+	return query, values
+}
+
+// Validate Window Query
+func (q Window[T, R]) Validate() error {
+	problems := q.conditionQuery.problems()
+	problems = append(problems, columnProblems(q.columns)...)
+	if len(q.functions) == 0 {
+		problems = append(problems, fmt.Errorf("window functions: %w", ErrMissingColumns))
+	}
+	if len(q.order) == 0 && len(q.functions) > 0 {
+		problems = append(problems, ErrMissingOrder)
+	}
+	problems = append(problems, q.invalid...)
+	return newValidationError(problems)
+}
+
+// Execute Window Query and get list of results
+func (q Window[T, R]) Query(dbc *sql.DB) ([]*R, error) {
+	reader := rdb.NewReader[R](q.resultColumns()...)
+	query, values, err := preReadCheck(q, dbc, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*R, 0)
+	err = readRows(q, dbc, query, values, reader, func(result *R) {
+		results = append(results, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,247 @@
+package rdb
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	JSONCodecName      string = "json"      // col:"name,json"
+	CommaListCodecName string = "commalist" // col:"name,commalist"
+	UnixTimeCodecName  string = "unixtime"  // col:"name,unixtime"
+)
+
+// Layout of text times converted by the UnixTime codec
+const unixTimeTextLayout string = "2006-01-02 15:04:05"
+
+// Codec converts field values to column values (Encode), and back (Decode)
+type Codec interface {
+	Encode(value any) (any, error)      // Convert Go value to column value
+	Decode(src any, fieldRef any) error // Convert column value into field pointer
+}
+
+var (
+	nameCodecs = map[string]Codec{ // {TagOption => Codec}
+		JSONCodecName:      jsonCodec{},
+		CommaListCodecName: commaListCodec{},
+		UnixTimeCodecName:  unixTimeCodec{},
+	}
+	typeCodecs = map[reflect.Type]Codec{} // {FieldType => Codec}
+)
+
+// Register codec for given col tag option
+func RegisterCodec(name string, codec Codec) {
+	nameCodecs[name] = codec
+}
+
+// Register codec for fields of type T
+func RegisterTypeCodec[T any](codec Codec) {
+	typeCodecs[reflect.TypeFor[T]()] = codec
+}
+
+// Get column codec: from col tag option first, then from field type
+func (o columnOptions) getCodec() Codec {
+	if codec, ok := nameCodecs[o.codec]; o.codec != "" && ok {
+		return codec
+	}
+	if o.fieldType == nil {
+		return nil
+	}
+	return typeCodecs[o.fieldType]
+}
+
+// Value that fails when passed to the driver,
+// used to surface codec encoding errors at query execution
+type failedValue struct {
+	err error
+}
+
+// Implement driver.Valuer: return the encoding error
+func (f failedValue) Value() (driver.Value, error) {
+	return nil, f.err
+}
+
+// Describe the encoding error, used when rendering query strings
+func (f failedValue) String() string {
+	return fmt.Sprintf("codec error: %v", f.err)
+}
+
+// Scanner that decodes column values using a codec
+type codecScanner struct {
+	fieldRef any // pointer to struct field
+	codec    Codec
+}
+
+// Implement sql.Scanner: NULL => zero value, otherwise decode into field
+func (c *codecScanner) Scan(src any) error {
+	if src == nil {
+		reflect.ValueOf(c.fieldRef).Elem().SetZero()
+		return nil
+	}
+	return c.codec.Decode(src, c.fieldRef)
+}
+
+// JSON codec: stores value as JSON text
+type jsonCodec struct{}
+
+// Encode value as JSON text
+func (jsonCodec) Encode(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Decode JSON text into field
+func (jsonCodec) Decode(src any, fieldRef any) error {
+	data, err := sourceBytes(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, fieldRef)
+}
+
+// Comma-list codec: stores slice as comma-separated text
+type commaListCodec struct{}
+
+// Encode slice as comma-separated text
+func (commaListCodec) Encode(value any) (any, error) {
+	slice := reflect.ValueOf(value)
+	if slice.Kind() != reflect.Slice && slice.Kind() != reflect.Array {
+		return nil, fmt.Errorf("commalist codec: %T is not a slice", value)
+	}
+	items := make([]string, slice.Len())
+	for i := range slice.Len() {
+		items[i] = fmt.Sprintf("%v", slice.Index(i).Interface())
+	}
+	return strings.Join(items, ","), nil
+}
+
+// Decode comma-separated text into slice field
+func (commaListCodec) Decode(src any, fieldRef any) error {
+	data, err := sourceBytes(src)
+	if err != nil {
+		return err
+	}
+	dest := reflect.ValueOf(fieldRef).Elem()
+	if dest.Kind() != reflect.Slice {
+		return fmt.Errorf("commalist codec: %s is not a slice", dest.Type())
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		dest.Set(reflect.MakeSlice(dest.Type(), 0, 0))
+		return nil
+	}
+	parts := strings.Split(text, ",")
+	slice := reflect.MakeSlice(dest.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		err := convertAssign(slice.Index(i), strings.TrimSpace(part))
+		if err != nil {
+			return fmt.Errorf("commalist codec: item #%d: %w", i+1, err)
+		}
+	}
+	dest.Set(slice)
+	return nil
+}
+
+// Unix time codec: stores time.Time or datetime text (yyyy-mm-dd hh:mm:ss) as Unix seconds
+type unixTimeCodec struct{}
+
+// Encode time as Unix seconds
+func (unixTimeCodec) Encode(value any) (any, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Unix(), nil
+	case string:
+		t, err := time.ParseInLocation(unixTimeTextLayout, v, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("unixtime codec: %w", err)
+		}
+		return t.Unix(), nil
+	}
+	return nil, fmt.Errorf("unixtime codec: unsupported type %T", value)
+}
+
+// Decode Unix seconds into time.Time or datetime text field
+func (unixTimeCodec) Decode(src any, fieldRef any) error {
+	var seconds int64
+	err := convertAssign(reflect.ValueOf(&seconds).Elem(), src)
+	if err != nil {
+		return fmt.Errorf("unixtime codec: %w", err)
+	}
+	t := time.Unix(seconds, 0)
+	switch dest := fieldRef.(type) {
+	case *time.Time:
+		*dest = t
+	case *string:
+		*dest = t.Format(unixTimeTextLayout)
+	default:
+		return fmt.Errorf("unixtime codec: unsupported type %T", fieldRef)
+	}
+	return nil
+}
+
+// Enum codec: stores enum values as their string names
+type enumCodec[E comparable] struct {
+	names  map[E]string
+	values map[string]E
+}
+
+// Create new enum codec from {EnumValue => Name} mapping
+func NewEnumCodec[E comparable](names map[E]string) Codec {
+	values := make(map[string]E, len(names))
+	for value, name := range names {
+		values[name] = value
+	}
+	return enumCodec[E]{names, values}
+}
+
+// Encode enum value as its name
+func (c enumCodec[E]) Encode(value any) (any, error) {
+	enum, ok := value.(E)
+	if !ok {
+		return nil, fmt.Errorf("enum codec: unsupported type %T", value)
+	}
+	name, ok := c.names[enum]
+	if !ok {
+		return nil, fmt.Errorf("enum codec: unknown value %v", value)
+	}
+	return name, nil
+}
+
+// Decode name into enum field
+func (c enumCodec[E]) Decode(src any, fieldRef any) error {
+	data, err := sourceBytes(src)
+	if err != nil {
+		return err
+	}
+	dest, ok := fieldRef.(*E)
+	if !ok {
+		return fmt.Errorf("enum codec: unsupported type %T", fieldRef)
+	}
+	value, ok := c.values[string(data)]
+	if !ok {
+		return fmt.Errorf("enum codec: unknown name %q", string(data))
+	}
+	*dest = value
+	return nil
+}
+
+// Get bytes of text column value
+func sourceBytes(src any) ([]byte, error) {
+	switch v := src.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	case int64:
+		return []byte(strconv.FormatInt(v, 10)), nil
+	}
+	return nil, fmt.Errorf("cannot decode %T as text", src)
+}
@@ -1,24 +1,36 @@
 package rdb
 
 import (
-	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/roidaradal/fn/dict"
 	"github.com/roidaradal/fn/dyn"
 	"github.com/roidaradal/fn/str"
+	"github.com/roidaradal/rdb/internal/dialect"
 )
 
 const (
-	columnTag    string = "col" // struct tag used to defined column name
-	skipTagValue string = "-"   // set col:"-" to skip column
+	columnTag      string = "col"      // struct tag used to defined column name
+	skipTagValue   string = "-"        // set col:"-" to skip column
+	nullZeroOption string = "nullzero" // set col:"name,nullzero" to map NULL <=> zero value
 )
 
+// Column options, from col:"name,option1,option2" struct tag
+type columnOptions struct {
+	nullZero  bool         // NULL is scanned as zero value, zero value is written as NULL
+	codec     string       // name of codec from tag option
+	fieldType reflect.Type // field type, used for type codecs
+}
+
 type columnsInfo struct {
-	columns       []string       // list of column names
-	columnFields  dict.StringMap // {ColumnName => FieldName}
-	fieldColumns  dict.StringMap // {FieldName => ColumnName}
-	addressColumn dict.StringMap // {FieldAddress => ColumnName}
+	columns        []string                 // list of column names
+	columnFields   dict.StringMap           // {ColumnName => FieldName}
+	fieldColumns   dict.StringMap           // {FieldName => ColumnName}
+	addressColumn  dict.StringMap           // {FieldAddress => ColumnName}
+	columnOptions  map[string]columnOptions // {ColumnName => Options}
+	addressOptions map[string]columnOptions // {FieldAddress => Options}
+	unknownOptions []string                 // FieldName.option of unknown col tag options
 }
 
 // From given struct reference and type name, get field value for given column
@@ -36,10 +48,13 @@ func GetStructColumnValue(structRef any, typeName, columnName string) (any, bool
 // Get all columns and field names from given struct pointer
 func readStructColumns(structRef any) *columnsInfo {
 	result := &columnsInfo{
-		columns:       make([]string, 0),
-		columnFields:  make(dict.StringMap),
-		fieldColumns:  make(dict.StringMap),
-		addressColumn: make(dict.StringMap),
+		columns:        make([]string, 0),
+		columnFields:   make(dict.StringMap),
+		fieldColumns:   make(dict.StringMap),
+		addressColumn:  make(dict.StringMap),
+		columnOptions:  make(map[string]columnOptions),
+		addressOptions: make(map[string]columnOptions),
+		unknownOptions: make([]string, 0),
 	}
 
 	if !dyn.IsStructPointer(structRef) {
@@ -61,34 +76,85 @@ func readStructColumns(structRef any) *columnsInfo {
 			result.addressColumn = dict.Update(result.addressColumn, inner.addressColumn)
 			result.columnFields = dict.Update(result.columnFields, inner.columnFields)
 			result.fieldColumns = dict.Update(result.fieldColumns, inner.fieldColumns)
+			result.columnOptions = dict.Update(result.columnOptions, inner.columnOptions)
+			result.addressOptions = dict.Update(result.addressOptions, inner.addressOptions)
+			result.unknownOptions = append(result.unknownOptions, inner.unknownOptions...)
 		} else {
 			// Normal field
-			column := extractColumnName(structField)
+			column, options, unknown := extractColumnName(structField)
+			for _, option := range unknown {
+				result.unknownOptions = append(result.unknownOptions, fieldName+"."+option)
+			}
 			if column == "" {
 				continue // skip blank columns
 			}
 			column = str.WrapBackticks(column)
-			fieldAddress := fmt.Sprintf("%v", structValue.Field(idx).Addr().Interface())
+			fieldAddress := dyn.AddressOf(structValue.Field(idx).Addr().Interface())
 			result.columns = append(result.columns, column)
 			result.addressColumn[fieldAddress] = column
 			result.columnFields[column] = fieldName
 			result.fieldColumns[fieldName] = column
+			result.columnOptions[column] = options
+			result.addressOptions[fieldAddress] = options
 		}
 	}
 	return result
 }
 
-// Extract custom column name from struct tag if not skipped,
-// Defaults to field name
-func extractColumnName(structField reflect.StructField) string {
-	column := structField.Tag.Get(columnTag)
-	if column == skipTagValue {
+// Extract custom column name and options from struct tag if not skipped,
+// Column name defaults to field name; also returns unknown tag options
+func extractColumnName(structField reflect.StructField) (string, columnOptions, []string) {
+	options := columnOptions{fieldType: structField.Type}
+	unknown := make([]string, 0)
+	tagValue := structField.Tag.Get(columnTag)
+	if tagValue == skipTagValue {
 		// no column if skipped
-		return ""
-	} else if column != "" {
-		// use custom column name if not blank
-		return column
+		return "", options, unknown
+	}
+	column, rawOptions, _ := strings.Cut(tagValue, ",")
+	for _, option := range str.CleanSplit(rawOptions, ",") {
+		switch {
+		case option == "":
+			continue // no options
+		case option == nullZeroOption:
+			options.nullZero = true
+		case dict.HasKey(nameCodecs, option):
+			options.codec = option // registered codec name
+		default:
+			unknown = append(unknown, option)
+		}
+	}
+	column = strings.TrimSpace(column)
+	if column == "" {
+		// default: field name
+		column = structField.Name
+	}
+	return column, options, unknown
+}
+
+// Convert Go value to column value for writes (insert, update), using column options
+func (o columnOptions) toColumnValue(value any) any {
+	if o.isNullZero(value) {
+		return nil // zero value => NULL
+	}
+	return o.encode(value)
+}
+
+// Check if value is the zero value of a nullzero column, which is stored as NULL
+func (o columnOptions) isNullZero(value any) bool {
+	return o.nullZero && value != nil && dyn.IsZero(value)
+}
+
+// Convert Go value to column value using the column codec;
+// used by conditions, which keep zero values
+func (o columnOptions) encode(value any) any {
+	codec := o.getCodec()
+	if codec == nil || dialect.IsNull(value) {
+		return value
+	}
+	columnValue, err := codec.Encode(value)
+	if err != nil {
+		return failedValue{err} // fails at query execution
 	}
-	// default: field name
-	return structField.Name
+	return columnValue
 }
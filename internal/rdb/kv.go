@@ -1,17 +1,22 @@
 package rdb
 
-import "github.com/roidaradal/fn/list"
+import (
+	"github.com/roidaradal/fn/dyn"
+	"github.com/roidaradal/fn/list"
+)
 
 // Key-Value pair; key = column
 type Value struct {
 	column string
-	value  any
+	value  any // column value
+	raw    any // original Go value
 }
 
 // Key-Values pair; key = column, values = list
 type List struct {
 	column string
-	values []any
+	values []any // column values
+	raws   []any // original Go values
 }
 
 // Return Value's column and value
@@ -24,13 +29,48 @@ func (l List) Tuple() (string, []any) {
 	return l.column, l.values
 }
 
-// Create new KeyValue pair
+// Return Value's column and original Go value (before column options)
+func (v Value) RawTuple() (string, any) {
+	return v.column, v.raw
+}
+
+// Return List's column and original Go values (before column options)
+func (l List) RawTuple() (string, []any) {
+	return l.column, l.raws
+}
+
+// Create new KeyValue pair, used by conditions
 func KeyValue[T any](key *T, value T) *Value {
 	column := GetColumnName(key)
 	if column == "" {
 		return nil
 	}
-	return &Value{column, value}
+	options := addressOptions[dyn.AddressOf(key)]
+	return &Value{column, options.encode(value), value}
+}
+
+// Create new KeyValue pair for updates: zero value of nullzero column => NULL
+func KeyUpdate[T any](key *T, value T) *Value {
+	column := GetColumnName(key)
+	if column == "" {
+		return nil
+	}
+	options := addressOptions[dyn.AddressOf(key)]
+	return &Value{column, options.toColumnValue(value), value}
+}
+
+// Check if value is the zero value of a nullzero column, which is stored as NULL
+func IsKeyNullZero[T any](key *T, value T) bool {
+	return addressOptions[dyn.AddressOf(key)].isNullZero(value)
+}
+
+// Create new KeyValue pair with NULL value
+func KeyNull(key any) *Value {
+	column := GetColumnName(key)
+	if column == "" {
+		return nil
+	}
+	return &Value{column, nil, nil}
 }
 
 // Create new KeyList pair
@@ -39,15 +79,50 @@ func KeyList[T any](key *T, values []T) *List {
 	if column == "" {
 		return nil
 	}
-	values2 := list.ToAny(values)
-	return &List{column, values2}
+	options := addressOptions[dyn.AddressOf(key)]
+	raws := list.Map(values, func(value T) any {
+		return value
+	})
+	values2 := list.Map(raws, options.encode)
+	return &List{column, values2, raws}
 }
 
-// Create new KeyValue pair, get column from fieldName
+// Create new KeyValue pair, get column from fieldName; used by conditions
 func ColumnValue(typeName, fieldName string, value any) *Value {
 	column := getFieldColumnName(typeName, fieldName)
 	if column == "" {
 		return nil
 	}
-	return &Value{column, value}
+	options := getColumnOptions(typeName, column)
+	return &Value{column, options.encode(value), value}
+}
+
+// Create new KeyValue pair for updates, get column from fieldName: zero value of nullzero column => NULL
+func ColumnUpdate(typeName, fieldName string, value any) *Value {
+	column := getFieldColumnName(typeName, fieldName)
+	if column == "" {
+		return nil
+	}
+	options := getColumnOptions(typeName, column)
+	return &Value{column, options.toColumnValue(value), value}
+}
+
+// Check if value is the zero value of a nullzero column, get column from fieldName
+func IsColumnNullZero(typeName, fieldName string, value any) bool {
+	column := getFieldColumnName(typeName, fieldName)
+	if column == "" {
+		return false
+	}
+	return getColumnOptions(typeName, column).isNullZero(value)
+}
+
+// Create new KeyList pair, get column from fieldName
+func ColumnList(typeName, fieldName string, values []any) *List {
+	column := getFieldColumnName(typeName, fieldName)
+	if column == "" {
+		return nil
+	}
+	options := getColumnOptions(typeName, column)
+	values2 := list.Map(values, options.encode)
+	return &List{column, values2, values}
 }
@@ -3,6 +3,8 @@ package rdb
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/roidaradal/fn/dict"
 	"github.com/roidaradal/fn/dyn"
@@ -11,19 +13,23 @@ import (
 )
 
 var (
-	addressColumn    dict.StringMap            // {FieldAddress => ColumnName}
-	typeColumns      dict.StringListMap        // {TypeName => []ColumnNames}
-	typeColumnFields map[string]dict.StringMap // {TypeName => {ColumnName => FieldName}}
-	typeFieldColumns map[string]dict.StringMap // {TypeName => {FieldName => ColumnName}}
-	rowCreator       map[string]createRowFn    // {TypeName => CreateRowFn}
+	addressColumn     dict.StringMap                      // {FieldAddress => ColumnName}
+	addressOptions    map[string]columnOptions            // {FieldAddress => ColumnOptions}
+	typeColumns       dict.StringListMap                  // {TypeName => []ColumnNames}
+	typeColumnFields  map[string]dict.StringMap           // {TypeName => {ColumnName => FieldName}}
+	typeFieldColumns  map[string]dict.StringMap           // {TypeName => {FieldName => ColumnName}}
+	typeColumnOptions map[string]map[string]columnOptions // {TypeName => {ColumnName => ColumnOptions}}
+	rowCreator        map[string]createRowFn              // {TypeName => CreateRowFn}
 )
 
 // Initialize memo data structures
 func Initialize() {
 	addressColumn = make(dict.StringMap)
+	addressOptions = make(map[string]columnOptions)
 	typeColumns = make(dict.StringListMap)
 	typeColumnFields = make(map[string]dict.StringMap)
 	typeFieldColumns = make(map[string]dict.StringMap)
+	typeColumnOptions = make(map[string]map[string]columnOptions)
 	rowCreator = make(map[string]createRowFn)
 }
 
@@ -37,10 +43,15 @@ func AddType(structRef any) error {
 	typeName := dyn.TypeOf(structRef)
 
 	result := readStructColumns(structRef)
+	if len(result.unknownOptions) > 0 {
+		return fmt.Errorf("unknown col tag options in %s: %s", typeName, strings.Join(result.unknownOptions, ", "))
+	}
 	addressColumn = dict.Update(addressColumn, result.addressColumn)
+	addressOptions = dict.Update(addressOptions, result.addressOptions)
 	typeColumns[typeName] = result.columns
 	typeColumnFields[typeName] = result.columnFields
 	typeFieldColumns[typeName] = result.fieldColumns
+	typeColumnOptions[typeName] = result.columnOptions
 	rowCreator[typeName] = newRowCreator(typeName, result.columns)
 	return nil
 }
@@ -89,6 +100,19 @@ func GetFieldNames(typeName string, fieldRefs ...any) []string {
 	return fields
 }
 
+// Get column options for given type name's column
+func getColumnOptions(typeName, columnName string) columnOptions {
+	if dict.NoKey(typeColumnOptions, typeName) {
+		return columnOptions{}
+	}
+	return typeColumnOptions[typeName][columnName]
+}
+
+// Get field name for given type name's column; column is expected to be wrapped in backticks
+func GetColumnFieldName(typeName, columnName string) string {
+	return getColumnFieldName(typeName, columnName)
+}
+
 // Get field name for given type name's column
 func getColumnFieldName(typeName, columnName string) string {
 	if dict.NoKey(typeColumnFields, typeName) {
@@ -97,6 +121,11 @@ func getColumnFieldName(typeName, columnName string) string {
 	return typeColumnFields[typeName][columnName]
 }
 
+// Get column name (wrapped in backticks) for given type name's field
+func GetFieldColumnName(typeName, fieldName string) string {
+	return getFieldColumnName(typeName, fieldName)
+}
+
 // Get column name for given type name's field
 func getFieldColumnName(typeName, fieldName string) string {
 	if dict.NoKey(typeFieldColumns, typeName) {
@@ -34,7 +34,8 @@ func newRowCreator(typeName string, columns []string) createRowFn {
 			if !ok {
 				continue // skip if column value not found
 			}
-			row[column] = value
+			options := getColumnOptions(typeName, column)
+			row[column] = options.toColumnValue(value)
 		}
 		if len(row) != numColumns {
 			return emptyRow // return empty if some columns failed
@@ -77,7 +78,8 @@ func NewReader[T any](columns ...string) RowReader[T] {
 			if !ok {
 				continue // skip if column's field not found
 			}
-			fieldRefs = append(fieldRefs, fieldRef)
+			options := getColumnOptions(typeName, column)
+			fieldRefs = append(fieldRefs, options.scanTarget(fieldRef))
 		}
 		if len(fieldRefs) != numColumns {
 			// return nil if some columns failed
@@ -0,0 +1,138 @@
+package rdb
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Time layouts tried when scanning text into time.Time
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Scanner that stores NULL as the field's zero value
+type nullZero struct {
+	fieldRef any // pointer to struct field
+}
+
+// Get scan destination of field reference, using column options
+func (o columnOptions) scanTarget(fieldRef any) any {
+	if codec := o.getCodec(); codec != nil {
+		return &codecScanner{fieldRef, codec}
+	}
+	if o.nullZero {
+		return &nullZero{fieldRef}
+	}
+	return fieldRef
+}
+
+// Implement sql.Scanner: NULL => zero value, otherwise convert into field
+func (n *nullZero) Scan(src any) error {
+	dest := reflect.ValueOf(n.fieldRef).Elem()
+	if src == nil {
+		dest.SetZero()
+		return nil
+	}
+	return convertAssign(dest, src)
+}
+
+// Convert database value into destination value
+func convertAssign(dest reflect.Value, src any) error {
+	if scanner, ok := dest.Addr().Interface().(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+	if src == nil {
+		dest.SetZero()
+		return nil
+	}
+	if dest.Kind() == reflect.Pointer {
+		value := reflect.New(dest.Type().Elem())
+		if err := convertAssign(value.Elem(), src); err != nil {
+			return err
+		}
+		dest.Set(value)
+		return nil
+	}
+
+	if b, ok := src.([]byte); ok {
+		if dest.Kind() == reflect.Slice && dest.Type().Elem().Kind() == reflect.Uint8 {
+			dest.SetBytes(append([]byte{}, b...))
+			return nil
+		}
+		src = string(b) // parse the rest as text
+	}
+
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Type().AssignableTo(dest.Type()) {
+		dest.Set(srcValue)
+		return nil
+	}
+	if _, isTime := dest.Interface().(time.Time); isTime {
+		text, ok := src.(string)
+		if !ok {
+			return scanError(src, dest)
+		}
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, text); err == nil {
+				dest.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return scanError(src, dest)
+	}
+
+	text := asText(srcValue)
+	var err error
+	switch dest.Kind() {
+	case reflect.String:
+		dest.SetString(text)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(text)
+		dest.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(text, 10, dest.Type().Bits())
+		dest.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		u, err = strconv.ParseUint(text, 10, dest.Type().Bits())
+		dest.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(text, dest.Type().Bits())
+		dest.SetFloat(f)
+	default:
+		if !srcValue.Type().ConvertibleTo(dest.Type()) {
+			return scanError(src, dest)
+		}
+		dest.Set(srcValue.Convert(dest.Type()))
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", scanError(src, dest), err)
+	}
+	return nil
+}
+
+// Convert database value to text for parsing
+func asText(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, value.Type().Bits())
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
+
+// Error for unsupported scan conversion
+func scanError(src any, dest reflect.Value) error {
+	return fmt.Errorf("cannot scan %T into %s", src, dest.Type())
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/roidaradal/fn/check"
@@ -25,6 +26,9 @@ type SQLConnParams struct {
 	Username string `validate:"required"`
 	Password string `validate:"required"`
 	Database string `validate:"required"`
+	// Optional
+	ParseTime bool           // scan DATE and DATETIME columns into time.Time
+	Location  *time.Location // time zone of DATETIME values, defaults to UTC
 }
 
 // Create new MySQL DB connection pool
@@ -43,6 +47,8 @@ func NewSQLConnection(p *SQLConnParams) (*sql.DB, error) {
 		Addr:                 dbAddr,
 		DBName:               p.Database,
 		AllowNativePasswords: true,
+		ParseTime:            p.ParseTime,
+		Loc:                  p.Location,
 	}
 	dbc, err := sql.Open("mysql", dbCfg.FormatDSN())
 	if err != nil {
@@ -60,11 +66,13 @@ func NewSQLConnection(p *SQLConnParams) (*sql.DB, error) {
 var AddType = rdb.AddType
 
 var (
-	AllColumns = rdb.ColumnsOf      // Get all column names of given item's type
-	Column     = rdb.GetColumnName  // Get column name of given field pointer
-	Columns    = rdb.GetColumnNames // Get column names of given field pointers
-	Field      = rdb.GetFieldName   // Get field name of given field pointer
-	Fields     = rdb.GetFieldNames  // Get field names of given field pointers
+	AllColumns = rdb.ColumnsOf          // Get all column names of given item's type
+	Column     = rdb.GetColumnName      // Get column name of given field pointer
+	Columns    = rdb.GetColumnNames     // Get column names of given field pointers
+	Field      = rdb.GetFieldName       // Get field name of given field pointer
+	Fields     = rdb.GetFieldNames      // Get field names of given field pointers
+	FieldOf    = rdb.GetColumnFieldName // Get field name of given type name's column
+	ColumnOf   = rdb.GetFieldColumnName // Get column name of given type name's field
 )
 
 // Function that reads row values into struct
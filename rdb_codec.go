@@ -0,0 +1,25 @@
+package rdb
+
+import "github.com/roidaradal/rdb/internal/rdb"
+
+// Codec converts field values to column values (Encode), and back (Decode)
+type Codec = rdb.Codec
+
+const (
+	JSONCodec      = rdb.JSONCodecName      // col:"name,json": value stored as JSON text
+	CommaListCodec = rdb.CommaListCodecName // col:"name,commalist": slice stored as comma-separated text
+	UnixTimeCodec  = rdb.UnixTimeCodecName  // col:"name,unixtime": time.Time or DateTime text stored as Unix seconds
+)
+
+// Register codec for given col tag option
+var RegisterCodec = rdb.RegisterCodec
+
+// Register codec for fields of type T
+func RegisterTypeCodec[T any](codec Codec) {
+	rdb.RegisterTypeCodec[T](codec)
+}
+
+// Create new codec that stores enum values as their names
+func NewEnumCodec[E comparable](names map[E]string) Codec {
+	return rdb.NewEnumCodec(names)
+}
@@ -1,15 +1,71 @@
 package rdb
 
-import "github.com/roidaradal/rdb/internal/condition"
+import (
+	"slices"
+
+	"github.com/roidaradal/rdb/internal/condition"
+)
 
 // Condition interface
 type Condition = condition.Condition
 
+// Condition with child conditions (And, Or, Not)
+type ConditionNode = condition.Node
+
+// Condition on one column (Equal, In, Between, ...)
+type ConditionLeaf = condition.Leaf
+
+// Condition operators, used in FieldCondition
+const (
+	OpEqual        = condition.Equal
+	OpNotEqual     = condition.NotEqual
+	OpGreater      = condition.Greater
+	OpGreaterEqual = condition.GreaterEqual
+	OpLess         = condition.Less
+	OpLessEqual    = condition.LessEqual
+	OpIn           = condition.In
+	OpNotIn        = condition.NotIn
+	OpPrefix       = condition.Prefix
+	OpSuffix       = condition.Suffix
+	OpSubstring    = condition.Substring
+	OpBetween      = condition.Between
+	OpNotBetween   = condition.NotBetween
+	OpIsNull       = condition.IsNull
+	OpIsNotNull    = condition.IsNotNull
+	OpLike         = condition.Like
+	OpNotLike      = condition.NotLike
+	OpILike        = condition.ILike
+	OpEqualFold    = condition.EqualFold
+	OpRegexp       = condition.Regexp
+	OpNotRegexp    = condition.NotRegexp
+	OpAnd          = condition.And
+	OpOr           = condition.Or
+	OpNot          = condition.Not
+)
+
+// Single value operators accepted by FieldCondition
+var valueOperators = []string{
+	OpEqual, OpNotEqual, OpGreater, OpGreaterEqual, OpLess, OpLessEqual,
+	OpPrefix, OpSuffix, OpSubstring, OpLike, OpNotLike, OpILike, OpEqualFold, OpRegexp, OpNotRegexp,
+}
+
+var (
+	WalkCondition    = condition.Walk    // Visit condition tree in depth-first order
+	FlattenCondition = condition.Flatten // Merge nested And/Or, remove nil conditions, cancel double Not
+	FormatCondition  = condition.Format  // Pretty-print condition tree
+)
+
 // Create MatchAll condition
 func NoCondition() *condition.MatchAll {
 	return &condition.MatchAll{}
 }
 
+// Create FullTable condition: allows Update and Delete with condition that matches all rows
+// (e.g. NoCondition, or Or that collapses to true); nil condition = match all
+func AllowFullTable(c Condition) *condition.FullTable {
+	return condition.NewFullTable(c)
+}
+
 // Create Equal condition
 func Equal[T any](fieldRef *T, value T) *condition.Value {
 	return condition.NewValue(fieldRef, value, condition.Equal)
@@ -35,6 +91,46 @@ func Substring(fieldRef *string, value string) *condition.Value {
 	return condition.NewValue(fieldRef, value, condition.Substring)
 }
 
+// Create Like condition, value is a LIKE pattern (with % and _ wildcards)
+func Like(fieldRef *string, pattern string) *condition.Value {
+	return condition.NewValue(fieldRef, pattern, condition.Like)
+}
+
+// Create NotLike condition, value is a LIKE pattern (with % and _ wildcards)
+func NotLike(fieldRef *string, pattern string) *condition.Value {
+	return condition.NewValue(fieldRef, pattern, condition.NotLike)
+}
+
+// Create case-insensitive Like condition
+func ILike(fieldRef *string, pattern string) *condition.Value {
+	return condition.NewValue(fieldRef, pattern, condition.ILike)
+}
+
+// Create case-insensitive Equal condition
+func EqualFold(fieldRef *string, value string) *condition.Value {
+	return condition.NewValue(fieldRef, value, condition.EqualFold)
+}
+
+// Create Regexp condition
+func Regexp(fieldRef *string, pattern string) *condition.Value {
+	return condition.NewValue(fieldRef, pattern, condition.Regexp)
+}
+
+// Create NotRegexp condition
+func NotRegexp(fieldRef *string, pattern string) *condition.Value {
+	return condition.NewValue(fieldRef, pattern, condition.NotRegexp)
+}
+
+// Create IsNull condition
+func IsNull(fieldRef any) *condition.Value {
+	return condition.NewNullValue(fieldRef, condition.IsNull)
+}
+
+// Create IsNotNull condition
+func IsNotNull(fieldRef any) *condition.Value {
+	return condition.NewNullValue(fieldRef, condition.IsNotNull)
+}
+
 // Create Greater condition
 func Greater[T any](fieldRef *T, value T) *condition.Value {
 	return condition.NewValue(fieldRef, value, condition.Greater)
@@ -55,6 +151,16 @@ func LessEqual[T any](fieldRef *T, value T) *condition.Value {
 	return condition.NewValue(fieldRef, value, condition.LessEqual)
 }
 
+// Create Between condition (inclusive)
+func Between[T any](fieldRef *T, low, high T) *condition.Range {
+	return condition.NewRange(fieldRef, low, high, condition.Between)
+}
+
+// Create NotBetween condition
+func NotBetween[T any](fieldRef *T, low, high T) *condition.Range {
+	return condition.NewRange(fieldRef, low, high, condition.NotBetween)
+}
+
 // Create In condition
 func In[T any](fieldRef *T, values []T) *condition.List {
 	return condition.NewList(fieldRef, values, condition.In, condition.Equal)
@@ -74,3 +180,81 @@ func And(conditions ...Condition) *condition.Multi {
 func Or(conditions ...Condition) *condition.Multi {
 	return condition.NewMulti(condition.Or, conditions...)
 }
+
+// Create Not condition
+func Not(c Condition) *condition.Negation {
+	return condition.NewNegation(c)
+}
+
+// Create InQuery condition: field IN (subquery)
+func InQuery(fieldRef any, q Query) *condition.Subquery {
+	return condition.NewSubquery(fieldRef, q, condition.In)
+}
+
+// Create NotInQuery condition: field NOT IN (subquery)
+func NotInQuery(fieldRef any, q Query) *condition.Subquery {
+	return condition.NewSubquery(fieldRef, q, condition.NotIn)
+}
+
+// Create Exists condition: EXISTS (subquery)
+func Exists(q Query) *condition.Subquery {
+	return condition.NewExists(q, condition.Exists)
+}
+
+// Create NotExists condition: NOT EXISTS (subquery)
+func NotExists(q Query) *condition.Subquery {
+	return condition.NewExists(q, condition.NotExists)
+}
+
+// Create EqualQuery condition: field = (scalar subquery)
+func EqualQuery(fieldRef any, q Query) *condition.Subquery {
+	return condition.NewSubquery(fieldRef, q, condition.Equal)
+}
+
+// Create NotEqualQuery condition: field != (scalar subquery)
+func NotEqualQuery(fieldRef any, q Query) *condition.Subquery {
+	return condition.NewSubquery(fieldRef, q, condition.NotEqual)
+}
+
+// Create GreaterQuery condition: field > (scalar subquery)
+func GreaterQuery(fieldRef any, q Query) *condition.Subquery {
+	return condition.NewSubquery(fieldRef, q, condition.Greater)
+}
+
+// Create GreaterEqualQuery condition: field >= (scalar subquery)
+func GreaterEqualQuery(fieldRef any, q Query) *condition.Subquery {
+	return condition.NewSubquery(fieldRef, q, condition.GreaterEqual)
+}
+
+// Create LessQuery condition: field < (scalar subquery)
+func LessQuery(fieldRef any, q Query) *condition.Subquery {
+	return condition.NewSubquery(fieldRef, q, condition.Less)
+}
+
+// Create LessEqualQuery condition: field <= (scalar subquery)
+func LessEqualQuery(fieldRef any, q Query) *condition.Subquery {
+	return condition.NewSubquery(fieldRef, q, condition.LessEqual)
+}
+
+// Create condition using type's field name instead of field reference, for dynamic filters.
+// Operators: IsNull, IsNotNull (no values), In, NotIn (list), Between, NotBetween (2 values),
+// others (1 value). Wrong number of values, unknown field or unknown operator = false condition
+func FieldCondition(typeName, fieldName, operator string, values ...any) Condition {
+	switch operator {
+	case OpIsNull, OpIsNotNull:
+		return condition.NewFieldNullValue(typeName, fieldName, operator)
+	case OpIn:
+		return condition.NewFieldList(typeName, fieldName, values, condition.In, condition.Equal)
+	case OpNotIn:
+		return condition.NewFieldList(typeName, fieldName, values, condition.NotIn, condition.NotEqual)
+	case OpBetween, OpNotBetween:
+		if len(values) != 2 {
+			return &condition.Missing{}
+		}
+		return condition.NewFieldRange(typeName, fieldName, values[0], values[1], operator)
+	}
+	if !slices.Contains(valueOperators, operator) || len(values) != 1 {
+		return &condition.Missing{}
+	}
+	return condition.NewFieldValue(typeName, fieldName, values[0], operator)
+}
@@ -0,0 +1,24 @@
+package rdb
+
+import "testing"
+
+type conditionItem struct {
+	Name string
+}
+
+func TestFieldConditionUnknownOperator(t *testing.T) {
+	Initialize()
+	if err := AddType(&conditionItem{}); err != nil {
+		t.Fatal(err)
+	}
+	query, values := FieldCondition("conditionItem", "Name", OpEqual, "x").Build()
+	if query != "`Name` = ?" || len(values) != 1 {
+		t.Fatalf("Equal: got %q %v", query, values)
+	}
+	for _, operator := range []string{"= 1 OR 1 =", "IS", "", OpAnd} {
+		query, values = FieldCondition("conditionItem", "Name", operator, "x").Build()
+		if query != "false" || len(values) != 0 {
+			t.Errorf("operator %q: got %q %v, want false condition", operator, query, values)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package rdb
+
+import "github.com/roidaradal/rdb/internal/dialect"
+
+// SQL dialect
+type Dialect = dialect.Dialect
+
+const (
+	MySQL      = dialect.MySQL      // MySQL dialect (default)
+	PostgreSQL = dialect.PostgreSQL // PostgreSQL dialect
+	SQLite     = dialect.SQLite     // SQLite dialect
+)
+
+var (
+	SetDialect     = dialect.Set     // Set the current SQL dialect
+	CurrentDialect = dialect.Current // Get the current SQL dialect
+)
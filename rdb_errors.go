@@ -0,0 +1,42 @@
+package rdb
+
+import "github.com/roidaradal/rdb/internal/query"
+
+type (
+	QueryError      = query.QueryError      // Query error, with the operation, table, built SQL and arguments
+	ValidationError = query.ValidationError // Lists each problem that prevents the query from being built
+)
+
+var (
+	ErrEmptyQuery          = query.ErrEmptyQuery          // Query built to an empty string
+	ErrFailedResultCheck   = query.ErrFailedResultCheck   // Result checker rejected the rows affected
+	ErrFailedTypeAssertion = query.ErrFailedTypeAssertion // Column value is not of the requested type
+	ErrNoChecker           = query.ErrNoChecker           // Transaction query has no result checker
+	ErrNoDBConnection      = query.ErrNoDBConnection      // Database connection is nil
+	ErrNoDBTx              = query.ErrNoDBTx              // Database transaction is nil
+	ErrNoReader            = query.ErrNoReader            // Select query has no row reader
+	ErrNotFoundField       = query.ErrNotFoundField       // Column has no field in the struct type
+	ErrTooManyRows         = query.ErrTooManyRows         // Update or Delete affected more rows than the max rows affected
+	ErrMissingTable        = query.ErrMissingTable        // Build failure: table is not set
+	ErrMissingColumns      = query.ErrMissingColumns      // Build failure: columns are not set or not found
+	ErrMissingCondition    = query.ErrMissingCondition    // Build failure: condition is nil
+	ErrMissingOrder        = query.ErrMissingOrder        // Build failure: order is not set
+	ErrMissingRows         = query.ErrMissingRows         // Build failure: InsertRows has no rows
+	ErrUnknownField        = query.ErrUnknownField        // Build failure: field reference or name is not registered
+	ErrUnknownOperator     = query.ErrUnknownOperator     // Build failure: Having uses an operator other than =, !=, >, >=, <, <=
+	ErrMismatchedColumns   = query.ErrMismatchedColumns   // Build failure: InsertRows rows have different columns
+	ErrFullTable           = query.ErrFullTable           // Build failure: Update or Delete condition matches all rows, without AllowFullTable
+	ErrInvalidBucket       = query.ErrInvalidBucket       // Build failure: TimeSeries bucket or time range is invalid
+	ErrMissingLimit        = query.ErrMissingLimit        // Build failure: Delete has an order but no limit
+)
+
+var (
+	IsDuplicateKey        = query.IsDuplicateKey        // Check if error is a duplicate unique key violation
+	IsForeignKeyViolation = query.IsForeignKeyViolation // Check if error is a foreign key violation
+	IsDeadlock            = query.IsDeadlock            // Check if error is a deadlock or serialization failure
+	IsCheckViolation      = query.IsCheckViolation      // Check if error is a check constraint violation
+	IsNotNullViolation    = query.IsNotNullViolation    // Check if error is a NOT NULL violation
+	IsDataTooLong         = query.IsDataTooLong         // Check if error is a data too long error
+	IsNotFound            = query.IsNotFound            // Check if error is a no rows found error
+	ErrorColumn           = query.ErrorColumn           // Get column, key, or constraint name mentioned in driver error
+)
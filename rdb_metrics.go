@@ -0,0 +1,26 @@
+package rdb
+
+import "github.com/roidaradal/rdb/internal/metrics"
+
+type (
+	MetricsRecorder = metrics.Recorder // Collects counters and histograms
+	MetricLabels    = metrics.Labels   // Schema, Table, Operation, Outcome labels
+	ExpvarMetrics   = metrics.Expvar   // Expvar-backed MetricsRecorder (default)
+)
+
+var (
+	SetMetricsRecorder = metrics.SetRecorder        // Set the metrics recorder; nil disables metrics
+	NewExpvarMetrics   = metrics.NewExpvar          // Create new ExpvarMetrics with default buckets
+	NewExpvarBuckets   = metrics.NewExpvarBuckets   // Create new ExpvarMetrics with given histogram buckets
+	MetricsHandler     = metrics.Handler            // HTTP handler for Prometheus text exposition
+	ObserveSchema      = metrics.ObserveSchema      // Record Schema operation count and latency
+	ObserveTransaction = metrics.ObserveTransaction // Record transaction outcome
+)
+
+const (
+	TxCommit   = metrics.OutcomeCommit   // Transaction committed
+	TxRollback = metrics.OutcomeRollback // Transaction rolled back
+	TxFailed   = metrics.OutcomeFailed   // Commit or rollback failed
+)
+
+const ExpvarMetricsName = metrics.ExpvarName // Expvar name of the current ExpvarMetrics' metrics
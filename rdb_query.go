@@ -1,13 +1,37 @@
 package rdb
 
-import "github.com/roidaradal/rdb/internal/query"
+import (
+	"database/sql"
+
+	"github.com/roidaradal/rdb/internal/query"
+)
 
 type (
-	Query              = query.Query         // Query interface
-	ResultChecker      = query.ResultChecker // Checks SQL result if condition is satisfied
-	FieldUpdate        = query.FieldUpdate   // [OldValue, NewValue]
-	FieldUpdates       = query.FieldUpdates  // {FieldName => [OldValue, NewValue]}
-	UpdateQuery[T any] = query.Update[T]
+	Query                                = query.Query         // Query interface
+	ResultChecker                        = query.ResultChecker // Checks SQL result if condition is satisfied
+	FieldUpdate                          = query.FieldUpdate   // [OldValue, NewValue]
+	FieldUpdates                         = query.FieldUpdates  // {FieldName => [OldValue, NewValue]}
+	BatchResult                          = query.BatchResult   // Per-batch results and total rows affected
+	BatchQuery                           = query.BatchQuery    // Query that can be split into batches
+	InsertRowsQuery                      = query.InsertRows
+	UpdateQuery[T any]                   = query.Update[T]
+	BulkUpdateQuery[T any, K comparable] = query.BulkUpdate[T, K]
+	SelectRowsQuery[T any]               = query.SelectRows[T]
+	AggregateQuery[T, R any]             = query.Aggregate[T, R]
+	WindowQuery[T, R any]                = query.Window[T, R]
+	Group[K comparable, V any]           = query.Group[K, V]  // Group key and aggregate value
+	TimeBucket                           = query.TimeBucket   // Time bucket size of TimeSeries Query
+	Number                               = query.Number       // Numeric types, used by arithmetic updates, sums and time series
+	TimePoint[V query.Number]            = query.TimePoint[V] // Bucket start time and aggregate value
+	TimeSeriesQuery[V query.Number]      = query.TimeSeries[V]
+)
+
+// Time bucket sizes
+const (
+	HourBucket  = query.Hour
+	DayBucket   = query.Day
+	WeekBucket  = query.Week // weeks start on Monday
+	MonthBucket = query.Month
 )
 
 var (
@@ -17,15 +41,23 @@ var (
 	LastInsertID       = query.LastInsertID       // Get last insert ID from SQL result (defaults to 0)
 	Exec               = query.Exec               // Execute SQL query
 	ExecTx             = query.ExecTx             // Execute SQL query as part of transaction, rollback on any errors
+	ExecChecked        = query.ExecChecked        // Execute SQL query in its own transaction, rollback if result check fails
+	ExecBatches        = query.ExecBatches        // Execute InsertRows or BulkUpdate query in batches
+	ExecBatchesTx      = query.ExecBatchesTx      // Execute InsertRows or BulkUpdate query in batches as part of transaction, rollback on any errors
+	InsertIDs          = query.InsertIDs          // Execute InsertRows query in batches and get generated IDs, in row order
+	InsertIDsTx        = query.InsertIDsTx        // Execute InsertRows query in batches as part of transaction and get generated IDs
 	Rollback           = query.Rollback           // Rolls back SQL transaction
+	SetMaxRowsAffected = query.SetMaxRowsAffected // Set global max rows affected by Update and Delete queries (0 = no limit)
 )
 
 var (
-	QueryString        = query.ToString      // Build full query string
-	NewCountQuery      = query.NewCount      // Create new Count query
-	NewDeleteQuery     = query.NewDelete     // Create new Delete Query
-	NewInsertRowQuery  = query.NewInsertRow  // Create new InsertRow Query
-	NewInsertRowsQuery = query.NewInsertRows // Create new InsertRows Query
+	QueryString             = query.ToString           // Build full query string, values rendered in current dialect
+	DialectQueryString      = query.ToDialectString    // Build full query string, values rendered in given dialect
+	NewCountQuery           = query.NewCount           // Create new Count query
+	NewTimeSeriesCountQuery = query.NewTimeSeriesCount // Create new TimeSeries Query that counts rows per bucket
+	NewDeleteQuery          = query.NewDelete          // Create new Delete Query
+	NewInsertRowQuery       = query.NewInsertRow       // Create new InsertRow Query
+	NewInsertRowsQuery      = query.NewInsertRows      // Create new InsertRows Query
 )
 
 // Create new Update Query
@@ -33,11 +65,66 @@ func NewUpdateQuery[T any](table string) *query.Update[T] {
 	return query.NewUpdate[T](table)
 }
 
+// Create new BulkUpdate Query, with rows identified by key field
+func NewBulkUpdateQuery[T any, K comparable](table string, keyFieldRef *K) *query.BulkUpdate[T, K] {
+	return query.NewBulkUpdate[T](table, keyFieldRef)
+}
+
+// Create new BulkUpdate Query, with rows identified by key field name
+func NewBulkUpdateKeyQuery[T any, K comparable](table string, keyFieldName string) *query.BulkUpdate[T, K] {
+	return query.NewBulkUpdateKey[T, K](table, keyFieldName)
+}
+
 // Add field=value update to Update Query
 func Update[T any, V any](q *query.Update[T], fieldRef *V, value V) {
 	query.AddUpdate(q, fieldRef, value)
 }
 
+// Add field=NULL update to Update Query
+func UpdateNull[T any](q *query.Update[T], fieldRef any) {
+	query.AddNullUpdate(q, fieldRef)
+}
+
+// Add field = field + amount update to Update Query
+func UpdateIncrement[T any, V query.Number](q *query.Update[T], fieldRef *V, amount V) {
+	query.AddIncrementUpdate(q, fieldRef, amount)
+}
+
+// Add field = field - amount update to Update Query
+func UpdateDecrement[T any, V query.Number](q *query.Update[T], fieldRef *V, amount V) {
+	query.AddDecrementUpdate(q, fieldRef, amount)
+}
+
+// Add field = field * factor update to Update Query
+func UpdateMultiply[T any, V query.Number](q *query.Update[T], fieldRef *V, factor V) {
+	query.AddMultiplyUpdate(q, fieldRef, factor)
+}
+
+// Add field = sourceField update to Update Query
+func UpdateColumn[T, V any](q *query.Update[T], fieldRef, sourceFieldRef *V) {
+	query.AddColumnUpdate(q, fieldRef, sourceFieldRef)
+}
+
+// Add field = CURRENT_TIMESTAMP update to Update Query
+func UpdateNow[T any](q *query.Update[T], fieldRef any) {
+	query.AddNowUpdate(q, fieldRef)
+}
+
+// Add field = COALESCE(field, value) update to Update Query
+func UpdateCoalesce[T, V any](q *query.Update[T], fieldRef *V, value V) {
+	query.AddCoalesceUpdate(q, fieldRef, value)
+}
+
+// Add field = CASE WHEN condition THEN value ... ELSE field END update to Update Query
+func UpdateCase[T, V any](q *query.Update[T], fieldRef *V, whens ...query.When[V]) {
+	query.AddCaseUpdate(q, fieldRef, whens...)
+}
+
+// Create CASE WHEN condition THEN value branch, used in UpdateCase
+func When[V any](condition Condition, value V) query.When[V] {
+	return query.When[V]{Condition: condition, Value: value}
+}
+
 // Create new Value Query
 func NewValueQuery[T, V any](table string, fieldRef *V) *query.Value[T, V] {
 	return query.NewValue[T](table, fieldRef)
@@ -93,6 +180,31 @@ func NewGroupSumQuery[K comparable, V query.Number](table string, groupFieldRef
 	return query.NewGroupSum(table, groupFieldRef, sumFieldRef)
 }
 
+// Create new Aggregate Query, where T = source row type, R = result type
+func NewAggregateQuery[T, R any](table string) *query.Aggregate[T, R] {
+	return query.NewAggregate[T, R](table)
+}
+
+// Execute Aggregate Query and get map of results, keyed by given key function
+func AggregateMap[K comparable, T, R any](q *query.Aggregate[T, R], dbc *sql.DB, key func(*R) K) (map[K]*R, error) {
+	return query.AggregateMap(q, dbc, key)
+}
+
+// Create new Window Query, where T = source row type, R = result type that embeds T plus window value fields
+func NewWindowQuery[T, R any](table string) *query.Window[T, R] {
+	return query.NewWindow[T, R](table)
+}
+
+// Create new TimeSeries Query that sums field per bucket
+func NewTimeSeriesSumQuery[V query.Number](table string, timeFieldRef any, bucket query.TimeBucket, sumFieldRef *V) *query.TimeSeries[V] {
+	return query.NewTimeSeriesSum(table, timeFieldRef, bucket, sumFieldRef)
+}
+
+// Execute TimeSeries Query grouped by field and get map[group] => list of (bucket, value)
+func GroupSeries[K comparable, V query.Number](q *query.TimeSeries[V], dbc *sql.DB, groupFieldRef *K) (map[K][]query.TimePoint[V], error) {
+	return query.GroupSeries(q, dbc, groupFieldRef)
+}
+
 // Create new Sum Query
 func NewSumQuery[T any](table string, reader RowReader[T]) *query.SumQuery[T] {
 	return query.NewSum(table, reader)
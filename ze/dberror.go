@@ -0,0 +1,69 @@
+package ze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/roidaradal/fn/dyn"
+	"github.com/roidaradal/rdb"
+)
+
+// Classify database error: set the Request status and return a public error naming the offending field.
+// Message has no colons, as fail.PublicMessage only keeps the text up to the next colon.
+// Unclassified errors set status to Err500 and are returned as-is
+func classifyError[T any](rq *Request, err error) error {
+	var message string
+	switch {
+	case rdb.IsDuplicateKey(err):
+		rq.Status = Err409
+		message = "%s already exists"
+	case rdb.IsForeignKeyViolation(err):
+		rq.Status = Err422
+		message = "Invalid reference to %s"
+	case rdb.IsCheckViolation(err):
+		rq.Status = Err422
+		message = "Invalid value for %s"
+	case rdb.IsNotNullViolation(err):
+		rq.Status = Err400
+		message = "Missing value for %s"
+	case rdb.IsDataTooLong(err):
+		rq.Status = Err400
+		message = "%s is too long"
+	default:
+		rq.Status = Err500
+		return err
+	}
+	field := errorField[T](err)
+	if field == "" {
+		field = "item"
+	}
+	message = fmt.Sprintf(message, field)
+	return fmt.Errorf("public: %s: %w", message, err)
+}
+
+// Resolve the field name of the column, key, or constraint mentioned in the error,
+// using the type's registered columns
+func errorField[T any](err error) string {
+	hint := normalizeName(rdb.ErrorColumn(err))
+	if hint == "" {
+		return ""
+	}
+	var item T
+	typeName := dyn.TypeOf(&item)
+	bestField, bestLength := "", 0
+	for _, column := range rdb.AllColumns(&item) {
+		name := normalizeName(strings.Trim(column, "`"))
+		isMatch := hint == name || strings.Contains("_"+hint+"_", "_"+name+"_")
+		if isMatch && len(name) > bestLength {
+			// prefer exact or longest matching column name
+			bestField, bestLength = rdb.FieldOf(typeName, column), len(name)
+		}
+	}
+	return bestField
+}
+
+// Lowercase name and use underscore as word separator
+func normalizeName(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer(".", "_", "-", "_", " ", "_").Replace(name)
+}
@@ -0,0 +1,71 @@
+package ze
+
+import (
+	"time"
+
+	"github.com/roidaradal/rdb"
+)
+
+// Common: preview query at given table instead of executing it;
+// counts (and optionally reads) the rows matching condition, up to limit (0 = no limit)
+func dryRunAt[T any](rq *Request, q rdb.Query, condition rdb.Condition, limit uint, name, table, operation string) (int, error) {
+	if rq.DB == nil {
+		rq.AddLog("No DB connection")
+		rq.Status = Err500
+		return 0, errNoDBConnection
+	}
+	// Fail the same way as the real query (e.g. full table condition)
+	if v, ok := q.(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			rq.AddFmtLog("Dry run %s: invalid query", operation)
+			rq.Status = Err500
+			return 0, err
+		}
+	}
+	preview := &Preview{
+		Name:      name,
+		Table:     table,
+		Operation: operation,
+		Query:     rdb.QueryString(q),
+	}
+	rq.AddFmtLog("Dry run %s: %s", operation, preview.Query)
+
+	// Count affected rows
+	cq := rdb.NewCountQuery(table)
+	cq.Where(condition)
+	start := time.Now()
+	count, err := cq.Count(rq.DB)
+	observe(name, table, "dryRun", start, err)
+	if err != nil {
+		rq.AddFmtLog("Failed to count %s", name)
+		rq.Status = Err500
+		return 0, err
+	}
+	if limit > 0 {
+		count = min(count, int(limit))
+	}
+	preview.Count = count
+
+	// Read affected rows
+	if rq.DryRunSelect {
+		var t T
+		sq := rdb.NewFullSelectRowsQuery(table, rdb.FullReader(&t))
+		sq.Where(condition)
+		if limit > 0 {
+			sq.Limit(limit)
+		}
+		start = time.Now()
+		items, err := sq.Query(rq.DB)
+		observe(name, table, "dryRun", start, err)
+		if err != nil {
+			rq.AddFmtLog("Failed to get %s", name)
+			rq.Status = Err500
+			return 0, err
+		}
+		preview.Rows = items
+	}
+
+	rq.Previews = append(rq.Previews, preview)
+	rq.AddFmtLog("Dry run %s: %d %s", operation, count, name)
+	return count, nil
+}
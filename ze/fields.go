@@ -9,15 +9,17 @@ import (
 )
 
 const (
-	rdbTag   string = "rdb"  // Struct tag for rdb required and editable fields
-	fxTag    string = "fx"   // Struct tag for transformation function
-	required string = "must" // Struct tag value for required field
-	editable string = "edit" // Struct tag value for editable field
+	rdbTag   string = "rdb"     // Struct tag for rdb required and editable fields
+	fxTag    string = "fx"      // Struct tag for transformation function
+	required string = "must"    // Struct tag value for required field
+	editable string = "edit"    // Struct tag value for editable field
+	updated  string = "updated" // Struct tag value for UpdatedAt field
 )
 
 type fieldsInfo struct {
 	required     []string
 	editable     []string
+	updated      string // field set to request time on update
 	transformers map[string]TransformFn
 }
 
@@ -46,6 +48,9 @@ func getFieldsInfo(structRef any) fieldsInfo {
 			embedded := getFieldsInfo(embeddedStructRef)
 			fields.required = append(fields.required, embedded.required...)
 			fields.editable = append(fields.editable, embedded.editable...)
+			if embedded.updated != "" {
+				fields.updated = embedded.updated
+			}
 			fields.transformers = dict.Update(fields.transformers, embedded.transformers)
 		} else {
 			// Normal field
@@ -56,6 +61,8 @@ func getFieldsInfo(structRef any) fieldsInfo {
 					fields.required = append(fields.required, fieldName)
 				case editable:
 					fields.editable = append(fields.editable, fieldName)
+				case updated:
+					fields.updated = fieldName
 				}
 			}
 			fxKey := structField.Tag.Get(fxTag)
@@ -0,0 +1,331 @@
+package ze
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roidaradal/rdb"
+)
+
+const (
+	sortParam  string = "sort"  // sort=-CreatedAt,Name
+	limitParam string = "limit" // limit=20
+	pageParam  string = "page"  // page=2 (1-based)
+)
+
+// Default page size, used if page is set without limit
+var FilterPageSize uint = 20
+
+// Maximum limit, larger limits are clamped to it: 0 = no maximum
+var FilterMaxLimit uint = 1000
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Filter operators, {FilterOperator => rdb condition operator}
+var filterOperators = map[string]string{
+	"eq":         rdb.OpEqual,
+	"ne":         rdb.OpNotEqual,
+	"gt":         rdb.OpGreater,
+	"gte":        rdb.OpGreaterEqual,
+	"lt":         rdb.OpLess,
+	"lte":        rdb.OpLessEqual,
+	"in":         rdb.OpIn,
+	"nin":        rdb.OpNotIn,
+	"prefix":     rdb.OpPrefix,
+	"suffix":     rdb.OpSuffix,
+	"contains":   rdb.OpSubstring,
+	"null":       rdb.OpIsNull,
+	"notnull":    rdb.OpIsNotNull,
+	"between":    rdb.OpBetween,
+	"notbetween": rdb.OpNotBetween,
+	"like":       rdb.OpLike,
+	"notlike":    rdb.OpNotLike,
+	"ilike":      rdb.OpILike,
+	"equalfold":  rdb.OpEqualFold,
+	"regexp":     rdb.OpRegexp,
+	"notregexp":  rdb.OpNotRegexp,
+}
+
+// Operators that only apply to string fields
+var textOperators = []string{
+	rdb.OpPrefix, rdb.OpSuffix, rdb.OpSubstring, rdb.OpLike, rdb.OpNotLike,
+	rdb.OpILike, rdb.OpEqualFold, rdb.OpRegexp, rdb.OpNotRegexp,
+}
+
+// Operators that only apply to ordered fields: numbers, strings, times
+var orderedOperators = []string{
+	rdb.OpGreater, rdb.OpGreaterEqual, rdb.OpLess, rdb.OpLessEqual, rdb.OpBetween, rdb.OpNotBetween,
+}
+
+// Parsed filter: condition, ordering and pagination
+type Filter struct {
+	Condition rdb.Condition
+	Sort      []SortField
+	Limit     uint
+	Page      uint // 0 = no paging
+}
+
+// Sort field and direction
+type SortField struct {
+	Field  string
+	Column string
+	Desc   bool
+}
+
+// Parse filter from URL query parameters, only allowing the given filterable field names.
+// Syntax: field=value, field=op:value, field=in:a,b, field=between:a,b, field=null,
+// sort=-field1,field2, limit=20, page=2; field names are case and underscore-insensitive
+func (s Schema[T]) ParseFilter(rq *Request, params url.Values, filterable ...string) (*Filter, error) {
+	fields := s.filterableFields(filterable)
+	filter := &Filter{Sort: make([]SortField, 0)}
+	conditions := make([]rdb.Condition, 0)
+	var err error
+	for _, key := range slices.Sorted(maps.Keys(params)) {
+		values := params[key]
+		switch key {
+		case sortParam:
+			filter.Sort, err = s.parseSort(values, fields)
+		case limitParam:
+			filter.Limit, err = parseFilterCount(key, values)
+			if FilterMaxLimit > 0 {
+				filter.Limit = min(filter.Limit, FilterMaxLimit)
+			}
+		case pageParam:
+			filter.Page, err = parseFilterCount(key, values)
+		default:
+			var condition rdb.Condition
+			for _, value := range values {
+				condition, err = s.parseCondition(key, value, fields)
+				if err != nil {
+					break
+				}
+				conditions = append(conditions, condition)
+			}
+		}
+		if err != nil {
+			rq.AddErrorLog(err)
+			rq.Status = Err400
+			return nil, err
+		}
+	}
+	if len(conditions) > 0 {
+		filter.Condition = rdb.And(conditions...)
+	}
+	if filter.Page > 0 && filter.Limit == 0 {
+		filter.Limit = FilterPageSize
+	}
+	return filter, nil
+}
+
+// Apply filter's condition, ordering and pagination to SelectRows Query
+func ApplyFilter[T any](q *rdb.SelectRowsQuery[T], filter *Filter) {
+	if filter == nil {
+		return
+	}
+	if filter.Condition != nil {
+		q.Where(filter.Condition)
+	}
+	for _, sort := range filter.Sort {
+		if sort.Desc {
+			q.ThenDesc(sort.Column)
+		} else {
+			q.ThenAsc(sort.Column)
+		}
+	}
+	if filter.Page > 0 {
+		q.Page(filter.Page, filter.Limit)
+	} else if filter.Limit > 0 {
+		q.Limit(filter.Limit)
+	}
+}
+
+// Map normalized names of filterable fields to field names;
+// fields not found in the type are skipped
+func (s Schema[T]) filterableFields(filterable []string) map[string]reflect.StructField {
+	structType := reflect.TypeFor[T]()
+	fields := make(map[string]reflect.StructField, len(filterable))
+	for _, fieldName := range filterable {
+		field, ok := structType.FieldByName(fieldName)
+		if !ok || rdb.ColumnOf(s.Name, field.Name) == "" {
+			continue
+		}
+		fields[normalizeFilterName(fieldName)] = field
+	}
+	return fields
+}
+
+// Parse field=op:value parameter into condition
+func (s Schema[T]) parseCondition(key, value string, fields map[string]reflect.StructField) (rdb.Condition, error) {
+	field, ok := fields[normalizeFilterName(key)]
+	if !ok {
+		return nil, fmt.Errorf("public: Unknown filter field %s: %w", key, ErrInvalidFilter)
+	}
+	operator, text := rdb.OpEqual, value
+	if name, rest, found := strings.Cut(value, ":"); found && filterOperators[name] != "" {
+		operator, text = filterOperators[name], rest
+	} else if filterOperators[value] == rdb.OpIsNull || filterOperators[value] == rdb.OpIsNotNull {
+		operator, text = filterOperators[value], ""
+	}
+
+	fieldType := filterFieldType(field)
+	if !supportsOperator(fieldType, operator) {
+		return nil, fmt.Errorf("public: Unsupported filter operator for %s: %w", key, ErrInvalidFilter)
+	}
+	texts := make([]string, 0)
+	switch {
+	case operator == rdb.OpIsNull, operator == rdb.OpIsNotNull:
+		// no values
+	case isMultiValueOperator(operator):
+		texts = strings.Split(text, ",")
+	default:
+		texts = append(texts, text)
+	}
+	if isRangeOperator(operator) && len(texts) != 2 {
+		return nil, fmt.Errorf("public: Invalid filter range for %s: %w", key, ErrInvalidFilter)
+	}
+	values := make([]any, 0, len(texts))
+	for _, text := range texts {
+		value, err := parseFilterValue(fieldType, text)
+		if err != nil {
+			return nil, fmt.Errorf("public: Invalid filter value for %s: %w: %w", key, ErrInvalidFilter, err)
+		}
+		values = append(values, value)
+	}
+	return rdb.FieldCondition(s.Name, field.Name, operator, values...), nil
+}
+
+// Get field type used for filter values, pointers are dereferenced
+func filterFieldType(field reflect.StructField) reflect.Type {
+	if field.Type.Kind() == reflect.Pointer {
+		return field.Type.Elem()
+	}
+	return field.Type
+}
+
+// Check if operator can be used on field type
+func supportsOperator(fieldType reflect.Type, operator string) bool {
+	switch {
+	case slices.Contains(textOperators, operator):
+		return fieldType.Kind() == reflect.String
+	case slices.Contains(orderedOperators, operator):
+		return isOrderedType(fieldType)
+	}
+	return true
+}
+
+// Check if type can be compared with <, >: numbers, strings, times
+func isOrderedType(fieldType reflect.Type) bool {
+	if fieldType == timeType {
+		return true
+	}
+	switch fieldType.Kind() {
+	case reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// Check if operator takes a list of values: In, NotIn, Between, NotBetween
+func isMultiValueOperator(operator string) bool {
+	return operator == rdb.OpIn || operator == rdb.OpNotIn || isRangeOperator(operator)
+}
+
+// Check if operator takes a low and high value: Between, NotBetween
+func isRangeOperator(operator string) bool {
+	return operator == rdb.OpBetween || operator == rdb.OpNotBetween
+}
+
+// Parse sort=-field1,field2 parameter
+func (s Schema[T]) parseSort(params []string, fields map[string]reflect.StructField) ([]SortField, error) {
+	sorts := make([]SortField, 0)
+	for _, param := range params {
+		for name := range strings.SplitSeq(param, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			desc := strings.HasPrefix(name, "-")
+			name = strings.TrimLeft(name, "-+")
+			field, ok := fields[normalizeFilterName(name)]
+			if !ok {
+				return nil, fmt.Errorf("public: Unknown sort field %s: %w", name, ErrInvalidFilter)
+			}
+			column := rdb.ColumnOf(s.Name, field.Name)
+			sorts = append(sorts, SortField{field.Name, column, desc})
+		}
+	}
+	return sorts, nil
+}
+
+// Parse limit or page parameter: positive integer
+func parseFilterCount(key string, params []string) (uint, error) {
+	count, err := strconv.ParseUint(params[0], 10, 32)
+	if err != nil || count == 0 {
+		return 0, fmt.Errorf("public: Invalid %s: %w", key, ErrInvalidFilter)
+	}
+	return uint(count), nil
+}
+
+// Coerce text value into given field type
+func parseFilterValue(fieldType reflect.Type, text string) (any, error) {
+	text = strings.TrimSpace(text)
+	if fieldType == timeType {
+		for _, parse := range []func(string) (time.Time, error){ParseDateTime, ParseDate, parseRFC3339} {
+			if t, err := parse(text); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid time %q", text)
+	}
+	value := reflect.New(fieldType).Elem()
+	switch fieldType.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 10, fieldType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(text, 10, fieldType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, fieldType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		value.SetFloat(f)
+	default:
+		return nil, fmt.Errorf("unsupported field type %s", fieldType)
+	}
+	return value.Interface(), nil
+}
+
+// Parse RFC3339 time
+func parseRFC3339(text string) (time.Time, error) {
+	return time.Parse(time.RFC3339, text)
+}
+
+// Normalize filter name: lowercase, without underscores
+func normalizeFilterName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "")
+}
@@ -0,0 +1,234 @@
+package ze
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/roidaradal/fn/lang"
+	"github.com/roidaradal/rdb"
+)
+
+// JSON-serializable condition tree; each node is exactly one of:
+// field condition (Field, Op, Value or Values), And, Or, Not, or empty (match all);
+// Not of an empty node matches nothing
+type FilterNode struct {
+	Field  string        `json:"field,omitempty"`
+	Op     string        `json:"op,omitempty"`     // eq, ne, gt, gte, lt, lte, in, nin, between, null, ...
+	Value  any           `json:"value,omitempty"`  // single value operators
+	Values []any         `json:"values,omitempty"` // in, nin, between, notbetween
+	And    []*FilterNode `json:"and,omitempty"`
+	Or     []*FilterNode `json:"or,omitempty"`
+	Not    *FilterNode   `json:"not,omitempty"`
+}
+
+// Convert FilterNode tree into condition, validating fields against the Schema's type
+// and operators against the field types
+func (s Schema[T]) FilterCondition(rq *Request, node *FilterNode) (rdb.Condition, error) {
+	condition, err := s.nodeCondition(node)
+	if err != nil {
+		rq.AddErrorLog(err)
+		rq.Status = Err400
+		return nil, err
+	}
+	return condition, nil
+}
+
+// Convert condition into FilterNode tree;
+// Fails on conditions that cannot be serialized (e.g. subqueries)
+func (s Schema[T]) FilterNodeOf(condition rdb.Condition) (*FilterNode, error) {
+	if condition == nil {
+		return &FilterNode{}, nil
+	}
+	if node, ok := condition.(rdb.ConditionNode); ok {
+		children := make([]*FilterNode, 0, len(node.Children()))
+		for _, child := range node.Children() {
+			if child == nil && node.Operator() != rdb.OpNot {
+				continue // skip null conditions
+			}
+			childNode, err := s.FilterNodeOf(child)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, childNode)
+		}
+		switch node.Operator() {
+		case rdb.OpAnd, rdb.OpOr:
+			if len(children) == 0 {
+				return falseFilterNode(), nil // no conditions = false condition
+			}
+		}
+		switch node.Operator() {
+		case rdb.OpAnd:
+			return &FilterNode{And: children}, nil
+		case rdb.OpOr:
+			return &FilterNode{Or: children}, nil
+		default:
+			return &FilterNode{Not: children[0]}, nil
+		}
+	}
+	if leaf, ok := condition.(rdb.ConditionLeaf); ok {
+		column, operator, values := leaf.Parts()
+		isEquality := operator == rdb.OpEqual || operator == rdb.OpNotEqual
+		if isEquality && len(values) == 1 && isNilValue(values[0]) {
+			// Equal, NotEqual with NULL value
+			operator = lang.Ternary(operator == rdb.OpNotEqual, rdb.OpIsNotNull, rdb.OpIsNull)
+			values = []any{}
+		}
+		fieldName := rdb.FieldOf(s.Name, column)
+		if fieldName == "" {
+			return nil, fmt.Errorf("unknown column %q in %s condition: %w", column, s.Name, ErrInvalidFilter)
+		}
+		node := &FilterNode{Field: fieldName, Op: filterOperatorName(operator)}
+		if node.Op == "" {
+			return nil, fmt.Errorf("unsupported operator %q: %w", operator, ErrInvalidFilter)
+		}
+		if isMultiValueOperator(operator) {
+			node.Values = values
+		} else if len(values) == 1 {
+			node.Value = values[0]
+		}
+		return node, nil
+	}
+	// MatchAll = empty node, Missing = Not of empty node
+	switch query, _ := condition.Build(); query {
+	case "true":
+		return &FilterNode{}, nil
+	case "false":
+		return falseFilterNode(), nil
+	}
+	return nil, fmt.Errorf("unsupported condition %T: %w", condition, ErrInvalidFilter)
+}
+
+// Convert FilterNode into condition
+func (s Schema[T]) nodeCondition(node *FilterNode) (rdb.Condition, error) {
+	if node == nil {
+		return nil, fmt.Errorf("public: Missing filter: %w", ErrInvalidFilter)
+	}
+	numParts := 0
+	for _, isSet := range []bool{node.Field != "", node.And != nil, node.Or != nil, node.Not != nil} {
+		if isSet {
+			numParts += 1
+		}
+	}
+	switch {
+	case numParts > 1:
+		return nil, fmt.Errorf("public: Filter must have only one of field, and, or, not: %w", ErrInvalidFilter)
+	case numParts == 0:
+		return rdb.NoCondition(), nil
+	case node.And != nil, node.Or != nil:
+		children := node.And
+		if node.Or != nil {
+			children = node.Or
+		}
+		conditions := make([]rdb.Condition, len(children))
+		for i, child := range children {
+			condition, err := s.nodeCondition(child)
+			if err != nil {
+				return nil, err
+			}
+			conditions[i] = condition
+		}
+		if node.Or != nil {
+			return rdb.Or(conditions...), nil
+		}
+		return rdb.And(conditions...), nil
+	case node.Not != nil:
+		condition, err := s.nodeCondition(node.Not)
+		if err != nil {
+			return nil, err
+		}
+		return rdb.Not(condition), nil
+	}
+	return s.fieldCondition(node)
+}
+
+// Convert field FilterNode into condition
+func (s Schema[T]) fieldCondition(node *FilterNode) (rdb.Condition, error) {
+	field, ok := reflect.TypeFor[T]().FieldByName(node.Field)
+	if !ok || rdb.ColumnOf(s.Name, field.Name) == "" {
+		return nil, fmt.Errorf("public: Unknown filter field %s: %w", node.Field, ErrInvalidFilter)
+	}
+	opName := node.Op
+	if opName == "" {
+		opName = "eq"
+	}
+	operator, ok := filterOperators[opName]
+	if !ok {
+		return nil, fmt.Errorf("public: Unknown filter operator %s: %w", opName, ErrInvalidFilter)
+	}
+	fieldType := filterFieldType(field)
+	if !supportsOperator(fieldType, operator) {
+		return nil, fmt.Errorf("public: Unsupported filter operator for %s: %w", node.Field, ErrInvalidFilter)
+	}
+
+	rawValues := make([]any, 0)
+	switch {
+	case operator == rdb.OpIsNull, operator == rdb.OpIsNotNull:
+		// no values
+	case isMultiValueOperator(operator):
+		rawValues = node.Values
+	case node.Value == nil:
+		return nil, fmt.Errorf("public: Missing filter value for %s: %w", node.Field, ErrInvalidFilter)
+	default:
+		rawValues = append(rawValues, node.Value)
+	}
+	if isRangeOperator(operator) && len(rawValues) != 2 {
+		return nil, fmt.Errorf("public: Invalid filter range for %s: %w", node.Field, ErrInvalidFilter)
+	}
+	values := make([]any, 0, len(rawValues))
+	for _, rawValue := range rawValues {
+		value, err := coerceFilterValue(fieldType, rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("public: Invalid filter value for %s: %w: %w", node.Field, ErrInvalidFilter, err)
+		}
+		values = append(values, value)
+	}
+	return rdb.FieldCondition(s.Name, field.Name, operator, values...), nil
+}
+
+// Coerce JSON value into given field type:
+// strings are parsed as text, other values are converted through JSON
+func coerceFilterValue(fieldType reflect.Type, rawValue any) (any, error) {
+	if text, ok := rawValue.(string); ok {
+		return parseFilterValue(fieldType, text)
+	}
+	data, err := json.Marshal(rawValue)
+	if err != nil {
+		return nil, err
+	}
+	value := reflect.New(fieldType)
+	err = json.Unmarshal(data, value.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return value.Elem().Interface(), nil
+}
+
+// Check if value is nil or a nil pointer, map or slice
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// FilterNode that matches nothing
+func falseFilterNode() *FilterNode {
+	return &FilterNode{Not: &FilterNode{}}
+}
+
+// Get filter operator name of rdb condition operator
+func filterOperatorName(operator string) string {
+	for name, op := range filterOperators {
+		if op == operator {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package ze
+
+import (
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/roidaradal/rdb"
+)
+
+// Replica selection strategy
+type ReplicaStrategy int
+
+const (
+	RoundRobin   ReplicaStrategy = iota // replicas take turns
+	LeastLatency                        // replica with lowest moving average read latency
+)
+
+const (
+	latencyWeight   float64       = 0.2         // weight of new sample in moving average
+	latencyPenalty  time.Duration = time.Second // latency sample of failed reads
+	latencyRefresh  uint64        = 16          // every n-th LeastLatency pick is round-robin, to refresh averages
+	defaultReplicas string        = ""          // replica pool key of the default db connection
+)
+
+var (
+	replicaStrategy atomic.Int32                    // default: RoundRobin
+	replicaMap      = make(map[string]*replicaPool) // map of db connection key => replica pool
+)
+
+// Pool of read replica connections
+type replicaPool struct {
+	mu      sync.Mutex
+	conns   []*sql.DB
+	latency []time.Duration // moving average read latency, per replica
+	picks   uint64
+}
+
+// Add read replica of the default DB connection
+func AddReplica(dbConnParams *rdb.SQLConnParams) error {
+	return AddReplicaAt(defaultReplicas, dbConnParams)
+}
+
+// Add read replica of custom DB connection
+func AddReplicaAt(key string, dbConnParams *rdb.SQLConnParams) error {
+	replicaConn, err := rdb.NewSQLConnection(dbConnParams)
+	if err != nil {
+		return err
+	}
+	pool, ok := replicaMap[key]
+	if !ok {
+		pool = &replicaPool{}
+		replicaMap[key] = pool
+	}
+	pool.add(replicaConn)
+	return nil
+}
+
+// Set how replicas are selected for reads (default: RoundRobin)
+func SetReplicaStrategy(strategy ReplicaStrategy) {
+	replicaStrategy.Store(int32(strategy))
+}
+
+// Add replica connection to pool
+func (p *replicaPool) add(conn *sql.DB) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.conns = append(p.conns, conn)
+	p.latency = append(p.latency, 0)
+}
+
+// Select replica connection, nil if pool is empty
+func (p *replicaPool) pick() *sql.DB {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.conns) == 0 {
+		return nil
+	}
+	turn := p.picks
+	p.picks += 1
+	numConns := uint64(len(p.conns))
+	if ReplicaStrategy(replicaStrategy.Load()) != LeastLatency {
+		return p.conns[turn%numConns]
+	}
+	if turn%latencyRefresh == 0 {
+		// refresh picks take turns separately, so all replicas get sampled
+		return p.conns[(turn/latencyRefresh)%numConns]
+	}
+	best := 0
+	for i, latency := range p.latency {
+		if latency < p.latency[best] {
+			best = i // unmeasured replicas (0) are picked first
+		}
+	}
+	return p.conns[best]
+}
+
+// Update replica's moving average latency with read duration
+func (p *replicaPool) observe(conn *sql.DB, start time.Time, err error) {
+	sample := time.Since(start)
+	if err != nil && !rdb.IsNotFound(err) {
+		sample = max(sample, latencyPenalty)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, replicaConn := range p.conns {
+		if replicaConn != conn {
+			continue
+		}
+		if p.latency[i] == 0 {
+			p.latency[i] = sample
+		} else {
+			average := (1-latencyWeight)*float64(p.latency[i]) + latencyWeight*float64(sample)
+			p.latency[i] = time.Duration(average)
+		}
+		return
+	}
+}
+
+// Get DB connection for reads: a replica, unless inside a transaction,
+// ReadPrimary is set, or there are no replicas
+func (rq *Request) ReadDB() *sql.DB {
+	if rq.DBTx != nil || rq.ReadPrimary || rq.replicas == nil {
+		return rq.DB
+	}
+	if conn := rq.replicas.pick(); conn != nil {
+		return conn
+	}
+	return rq.DB
+}
+
+// Record read latency, if read used a replica
+func (rq *Request) observeRead(conn *sql.DB, start time.Time, err error) {
+	if rq.replicas != nil && conn != rq.DB {
+		rq.replicas.observe(conn, start, err)
+	}
+}
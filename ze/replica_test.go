@@ -0,0 +1,34 @@
+package ze
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestLeastLatencyRefreshesAllReplicas(t *testing.T) {
+	SetReplicaStrategy(LeastLatency)
+	defer SetReplicaStrategy(RoundRobin)
+	conns := []*sql.DB{{}, {}}
+	pool := &replicaPool{}
+	for _, conn := range conns {
+		pool.add(conn)
+	}
+	pool.latency[0] = time.Millisecond
+	pool.latency[1] = latencyPenalty // penalized replica
+
+	refreshPicks := make(map[*sql.DB]int)
+	for turn := range 4 * latencyRefresh {
+		conn := pool.pick()
+		if turn%latencyRefresh == 0 {
+			refreshPicks[conn] += 1
+		} else if conn != conns[0] {
+			t.Fatalf("turn %d: picked slower replica", turn)
+		}
+	}
+	for i, conn := range conns {
+		if refreshPicks[conn] != 2 {
+			t.Errorf("replica %d: got %d refresh picks, want 2", i, refreshPicks[conn])
+		}
+	}
+}
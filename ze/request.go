@@ -9,6 +9,7 @@ import (
 
 	"github.com/roidaradal/fn/clock"
 	"github.com/roidaradal/fn/dict"
+	"github.com/roidaradal/fn/lang"
 	"github.com/roidaradal/fn/str"
 	"github.com/roidaradal/rdb"
 )
@@ -30,14 +31,33 @@ type Request struct {
 	Checker rdb.ResultChecker
 	Status  int
 	Now     DateTime
+	NowTime time.Time
+	// Dry run: destructive queries (Update, Delete, SetFlag, Toggle) count the affected rows
+	// instead of writing, DryRunSelect also reads the affected rows
+	DryRun       bool
+	DryRunSelect bool
+	Previews     []*Preview
+	// Read replicas: reads use the primary DB if ReadPrimary is set (e.g. to read own writes)
+	ReadPrimary bool
 	// Private fields
-	start   DateTime
-	txSteps []rdb.Query
+	start    time.Time
+	txSteps  []rdb.Query
+	replicas *replicaPool
 	// Logs
 	mu   sync.RWMutex
 	logs []string
 }
 
+// Preview of a dry-run query: what it would change
+type Preview struct {
+	Name      string // schema name
+	Table     string
+	Operation string // update, delete, setFlag, ...
+	Query     string // SQL that would be executed, rendered using rdb.QueryString
+	Count     int    // number of rows that would be affected
+	Rows      any    // []*T of affected rows, if DryRunSelect
+}
+
 // Create new Request
 func NewRequest(name string, args ...any) (*Request, error) {
 	if len(args) > 0 {
@@ -49,6 +69,7 @@ func NewRequest(name string, args ...any) (*Request, error) {
 		return rq, errNoDBConnection
 	}
 	rq.DB = dbConn
+	rq.replicas = replicaMap[defaultReplicas]
 	return rq, nil
 }
 
@@ -64,6 +85,7 @@ func NewRequestAt(key, name string, args ...any) (*Request, error) {
 		return rq, errNoDBConnection
 	}
 	rq.DB = conn
+	rq.replicas = replicaMap[key]
 	return rq, nil
 }
 
@@ -73,7 +95,7 @@ func newRequest(name string) *Request {
 		Name:   name,
 		Params: make(dict.Object),
 		Status: OK200,
-		start:  clock.DateTimeNow(),
+		start:  Now(),
 		logs:   make([]string, 0),
 	}
 }
@@ -81,20 +103,38 @@ func newRequest(name string) *Request {
 // Create a subrequest for concurrent tasks
 func (rq *Request) SubRequest() *Request {
 	return &Request{
-		Task:   rq.Task,
-		Params: rq.Params,
-		DB:     rq.DB,
-		Status: OK200,
-		logs:   make([]string, 0),
+		Task:         rq.Task,
+		Params:       rq.Params,
+		DB:           rq.DB,
+		DryRun:       rq.DryRun,
+		DryRunSelect: rq.DryRunSelect,
+		ReadPrimary:  rq.ReadPrimary,
+		replicas:     rq.replicas,
+		Status:       OK200,
+		logs:         make([]string, 0),
 	}
 }
 
-// Set Now field
+// Set Now and NowTime fields
 func (rq *Request) SetNow() DateTime {
-	rq.Now = clock.DateTimeNow()
+	rq.NowTime = Now()
+	rq.Now = FormatDateTime(rq.NowTime)
 	return rq.Now
 }
 
+// Get request time: NowTime if set, otherwise current time
+func (rq *Request) Time() time.Time {
+	if rq.NowTime.IsZero() {
+		return Now()
+	}
+	return rq.NowTime
+}
+
+// Get request start time
+func (rq *Request) StartTime() time.Time {
+	return rq.start
+}
+
 // Combine logs with newline
 func (rq *Request) Output() string {
 	return strings.Join(rq.logs, "\n")
@@ -165,6 +205,7 @@ func (rq *Request) CommitTransaction() error {
 		return errNoDBTx
 	}
 	err := rq.DBTx.Commit()
+	rdb.ObserveTransaction(lang.Ternary(err == nil, rdb.TxCommit, rdb.TxFailed))
 	if err != nil {
 		for i, q := range rq.txSteps {
 			rq.AddFmtLog("Query %d: %s", i+1, rdb.QueryString(q))
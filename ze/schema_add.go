@@ -2,10 +2,14 @@ package ze
 
 import (
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/roidaradal/fn/check"
+	"github.com/roidaradal/fn/dict"
 	"github.com/roidaradal/fn/dyn"
 	"github.com/roidaradal/fn/fail"
+	"github.com/roidaradal/fn/lang"
 	"github.com/roidaradal/fn/list"
 	"github.com/roidaradal/rdb"
 )
@@ -86,26 +90,46 @@ func (s Schema[T]) InsertTxIDAt(rqtx *Request, item *T, table string) (ID, error
 	return insertAt(rqtx, item, s.Name, table, true, true)
 }
 
-// InsertRowsQuery at schema.Table
-func (s Schema[T]) InsertRows(rq *Request, items []*T) error {
+// InsertRowsQuery at schema.Table, return per-batch results and total rows inserted
+func (s Schema[T]) InsertRows(rq *Request, items []*T) (*rdb.BatchResult, error) {
 	return insertRowsAt(rq, items, s.Name, s.Table, false)
 }
 
-// InsertRowsQuery at table
-func (s Schema[T]) InsertRowsAt(rq *Request, items []*T, table string) error {
+// InsertRowsQuery at table, return per-batch results and total rows inserted
+func (s Schema[T]) InsertRowsAt(rq *Request, items []*T, table string) (*rdb.BatchResult, error) {
 	return insertRowsAt(rq, items, s.Name, table, false)
 }
 
-// InsertRowsQuery transaction at schema.Table
-func (s Schema[T]) InsertTxRows(rqtx *Request, items []*T) error {
+// InsertRowsQuery transaction at schema.Table, return per-batch results and total rows inserted
+func (s Schema[T]) InsertTxRows(rqtx *Request, items []*T) (*rdb.BatchResult, error) {
 	return insertRowsAt(rqtx, items, s.Name, s.Table, true)
 }
 
-// InsertRowsQuery transaction at table
-func (s Schema[T]) InsertTxRowsAt(rqtx *Request, items []*T, table string) error {
+// InsertRowsQuery transaction at table, return per-batch results and total rows inserted
+func (s Schema[T]) InsertTxRowsAt(rqtx *Request, items []*T, table string) (*rdb.BatchResult, error) {
 	return insertRowsAt(rqtx, items, s.Name, table, true)
 }
 
+// InsertRowsQuery at schema.Table, get generated IDs and set them into items
+func (s Schema[T]) InsertRowsID(rq *Request, items []*T) ([]ID, error) {
+	return insertRowsIDAt(rq, items, s.Name, s.Table, false)
+}
+
+// InsertRowsQuery at table, get generated IDs and set them into items
+func (s Schema[T]) InsertRowsIDAt(rq *Request, items []*T, table string) ([]ID, error) {
+	return insertRowsIDAt(rq, items, s.Name, table, false)
+}
+
+// InsertRowsQuery transaction at schema.Table, get generated IDs and set them into items
+func (s Schema[T]) InsertTxRowsID(rqtx *Request, items []*T) ([]ID, error) {
+	return insertRowsIDAt(rqtx, items, s.Name, s.Table, true)
+}
+
+// InsertRowsQuery transaction at table, get generated IDs and set them into items
+func (s Schema[T]) InsertTxRowsIDAt(rqtx *Request, items []*T, table string) ([]ID, error) {
+	return insertRowsIDAt(rqtx, items, s.Name, table, true)
+}
+
 // Common: create and execute InsertRowQuery at given table
 func insertAt[T any](rq *Request, item *T, name, table string, getID bool, isTx bool) (ID, error) {
 	var id ID = 0
@@ -123,16 +147,17 @@ func insertAt[T any](rq *Request, item *T, name, table string, getID bool, isTx
 	// Execute InsertRowQuery
 	var result *sql.Result
 	var err error
+	start := time.Now()
 	if isTx {
 		rq.AddTxStep(q)
 		result, err = rdb.ExecTx(q, rq.DBTx, rq.Checker)
 	} else {
 		result, err = rdb.Exec(q, rq.DB)
 	}
+	observe(name, table, "insert", start, err)
 	if err != nil {
 		rq.AddFmtLog("Failed to insert %s", name)
-		rq.Status = Err500
-		return id, err
+		return id, classifyError[T](rq, err)
 	}
 	rowsAffected := rdb.RowsAffected(result)
 
@@ -159,12 +184,13 @@ func insertAt[T any](rq *Request, item *T, name, table string, getID bool, isTx
 	return id, nil
 }
 
-// Common: create and execute InsertRowsQuery at given table
-func insertRowsAt[T any](rq *Request, items []*T, name, table string, isTx bool) error {
+// Common: create and execute InsertRowsQuery at given table, in batches within placeholder and size limits;
+// if not in a transaction, multiple batches are inserted in their own transaction (all or nothing)
+func insertRowsAt[T any](rq *Request, items []*T, name, table string, isTx bool) (*rdb.BatchResult, error) {
 	// Check that items are set
 	if items == nil {
 		rq.AddLog("Items to be added are not set")
-		return fail.MissingParams
+		return nil, fail.MissingParams
 	}
 	numItems := len(items)
 
@@ -173,31 +199,139 @@ func insertRowsAt[T any](rq *Request, items []*T, name, table string, isTx bool)
 	q := rdb.NewInsertRowsQuery(table)
 	q.Rows(rows)
 
-	// Execute InsertRowsQuery
-	var result *sql.Result
+	// Execute InsertRowsQuery in batches
+	var result *rdb.BatchResult
 	var err error
-	if isTx {
+	start := time.Now()
+	switch {
+	case isTx:
 		rq.AddTxStep(q)
-		checker := rdb.AssertRowsAffected(numItems)
-		result, err = rdb.ExecTx(q, rq.DBTx, checker)
-	} else {
-		result, err = rdb.Exec(q, rq.DB)
+		result, err = rdb.ExecBatchesTx(q, rq.DBTx, rdb.AssertNothing)
+	case len(q.Batches()) > 1:
+		result, err = insertBatchesTx(rq, q, numItems)
+	default:
+		result, err = rdb.ExecBatches(q, rq.DB)
 	}
+	observe(name, table, "insertRows", start, err)
 	if err != nil {
 		rq.AddFmtLog("Failed to insert %d %s rows", numItems, name)
-		rq.Status = Err500
-		return err
+		return nil, classifyError[T](rq, err)
 	}
-	rowsAffected := rdb.RowsAffected(result)
+	rowsAffected := result.RowsAffected
 
-	// If not transaction, check if rowsAffected == numItems
-	if !isTx && rowsAffected != numItems {
+	// Check if rowsAffected == numItems, rollback if transaction
+	if rowsAffected != numItems {
 		rq.AddFmtLog("Insert count mismatch: items = %d, rows = %d", numItems, rowsAffected)
 		rq.Status = Err500
-		return errMismatchCount
+		if isTx {
+			return nil, rdb.Rollback(rq.DBTx, errMismatchCount)
+		}
+		return nil, errMismatchCount
 	}
 
-	rq.AddFmtLog("Added: %d %s", rowsAffected, name)
+	rq.AddFmtLog("Added: %d %s in %d batches", rowsAffected, name, len(result.Results))
 	rq.Status = OK201
+	return result, nil
+}
+
+// Common: create and execute InsertRowsQuery at given table and get the generated IDs, in item order;
+// the ID column is omitted so the database generates the IDs, which are set into items that implement SetID
+func insertRowsIDAt[T any](rq *Request, items []*T, name, table string, isTx bool) ([]ID, error) {
+	// Check that items are set
+	if items == nil {
+		rq.AddLog("Items to be added are not set")
+		return nil, fail.MissingParams
+	}
+	numItems := len(items)
+
+	// Check that type has ID column
+	idColumn := rdb.ColumnOf(name, idField)
+	if idColumn == "" {
+		rq.AddFmtLog("No ID column in %s", name)
+		rq.Status = Err500
+		return nil, errNoIDColumn
+	}
+
+	// Build InsertRowsQuery, without the ID column
+	rows := list.Map(items, func(item *T) dict.Object {
+		row := rdb.ToRow(item)
+		delete(row, idColumn)
+		return row
+	})
+	q := rdb.NewInsertRowsQuery(table)
+	q.Rows(rows)
+
+	// Execute InsertRowsQuery in batches and get IDs
+	var ids []ID
+	var err error
+	start := time.Now()
+	switch {
+	case isTx:
+		rq.AddTxStep(q)
+		ids, err = rdb.InsertIDsTx(q, rq.DBTx, idColumn)
+	case len(q.Batches()) > 1:
+		err = runInTransaction(rq, func(dbtx *sql.Tx) error {
+			ids, err = rdb.InsertIDsTx(q, dbtx, idColumn)
+			return err
+		})
+	default:
+		ids, err = rdb.InsertIDs(q, rq.DB, idColumn)
+	}
+	observe(name, table, "insertRowsID", start, err)
+	if err != nil {
+		rq.AddFmtLog("Failed to insert %d %s rows", numItems, name)
+		return nil, classifyError[T](rq, err)
+	}
+
+	// Set IDs into items
+	for i, item := range items {
+		if setter, ok := any(item).(idSetter); ok {
+			setter.SetID(ids[i])
+		}
+	}
+
+	rq.AddFmtLog("Added: %d %s", len(ids), name)
+	rq.Status = OK201
+	return ids, nil
+}
+
+// Execute InsertRowsQuery batches in a new transaction, rollback if not all items are inserted
+func insertBatchesTx(rq *Request, q *rdb.InsertRowsQuery, numItems int) (*rdb.BatchResult, error) {
+	var result *rdb.BatchResult
+	err := runInTransaction(rq, func(dbtx *sql.Tx) error {
+		var err error
+		result, err = rdb.ExecBatchesTx(q, dbtx, rdb.AssertNothing)
+		if err != nil {
+			return err // already rolled back
+		}
+		if result.RowsAffected != numItems {
+			return rdb.Rollback(dbtx, errMismatchCount)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Run task in a new transaction and commit; task rolls back the transaction on errors
+func runInTransaction(rq *Request, task func(*sql.Tx) error) error {
+	if rq.DB == nil {
+		return errNoDBConnection
+	}
+	dbtx, err := rq.DB.Begin()
+	if err != nil {
+		return err
+	}
+	err = task(dbtx)
+	if err != nil {
+		return err
+	}
+	err = dbtx.Commit()
+	rdb.ObserveTransaction(lang.Ternary(err == nil, rdb.TxCommit, rdb.TxFailed))
+	if err != nil {
+		return fmt.Errorf("dbtx commit error: %w", err)
+	}
 	return nil
 }
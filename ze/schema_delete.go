@@ -2,6 +2,7 @@ package ze
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/roidaradal/fn/fail"
 	"github.com/roidaradal/rdb"
@@ -9,50 +10,71 @@ import (
 
 // DeleteQuery at schema.Table
 func (s Schema[T]) Delete(rq *Request, condition rdb.Condition) error {
-	_, err := deleteAt(rq, condition, s.Name, s.Table, false)
+	_, err := deleteAt[T](rq, condition, 0, nil, s.Name, s.Table, false)
 	return err
 }
 
 // DeleteQuery at table
 func (s Schema[T]) DeleteAt(rq *Request, condition rdb.Condition, table string) error {
-	_, err := deleteAt(rq, condition, s.Name, table, false)
+	_, err := deleteAt[T](rq, condition, 0, nil, s.Name, table, false)
 	return err
 }
 
 // DeleteQuery transaction at schema.Table
 func (s Schema[T]) DeleteTx(rqtx *Request, condition rdb.Condition) error {
-	_, err := deleteAt(rqtx, condition, s.Name, s.Table, true)
+	_, err := deleteAt[T](rqtx, condition, 0, nil, s.Name, s.Table, true)
 	return err
 }
 
 // DeleteQuery transaction at table
 func (s Schema[T]) DeleteTxAt(rqtx *Request, condition rdb.Condition, table string) error {
-	_, err := deleteAt(rqtx, condition, s.Name, table, true)
+	_, err := deleteAt[T](rqtx, condition, 0, nil, s.Name, table, true)
 	return err
 }
 
 // DeleteQuery at schema.Table, return rowsAffected
 func (s Schema[T]) CountDelete(rq *Request, condition rdb.Condition) (int, error) {
-	return deleteAt(rq, condition, s.Name, s.Table, false)
+	return deleteAt[T](rq, condition, 0, nil, s.Name, s.Table, false)
 }
 
 // DeleteQuery at table, return rowsAffected
 func (s Schema[T]) CountDeleteAt(rq *Request, condition rdb.Condition, table string) (int, error) {
-	return deleteAt(rq, condition, s.Name, table, false)
+	return deleteAt[T](rq, condition, 0, nil, s.Name, table, false)
 }
 
 // DeleteQuery transaction at schema.Table, return rowsAffected
 func (s Schema[T]) CountDeleteTx(rqtx *Request, condition rdb.Condition) (int, error) {
-	return deleteAt(rqtx, condition, s.Name, s.Table, true)
+	return deleteAt[T](rqtx, condition, 0, nil, s.Name, s.Table, true)
 }
 
 // DeleteQuery transaction at table, return rowsAffected
 func (s Schema[T]) CountDeleteTxAt(rqtx *Request, condition rdb.Condition, table string) (int, error) {
-	return deleteAt(rqtx, condition, s.Name, table, true)
+	return deleteAt[T](rqtx, condition, 0, nil, s.Name, table, true)
 }
 
-// Common: create and execute DeleteQuery at given table using condition
-func deleteAt(rq *Request, condition rdb.Condition, name, table string, isTx bool) (int, error) {
+// DeleteQuery at schema.Table, in its own transaction: rollback if checker fails, return rowsAffected
+func (s Schema[T]) DeleteChecked(rq *Request, condition rdb.Condition, checker rdb.ResultChecker) (int, error) {
+	return deleteAt[T](rq, condition, 0, checker, s.Name, s.Table, false)
+}
+
+// DeleteQuery at table, in its own transaction: rollback if checker fails, return rowsAffected
+func (s Schema[T]) DeleteCheckedAt(rq *Request, condition rdb.Condition, checker rdb.ResultChecker, table string) (int, error) {
+	return deleteAt[T](rq, condition, 0, checker, s.Name, table, false)
+}
+
+// DeleteQuery at schema.Table, in chunks of batchSize rows until exhausted, return total rowsAffected
+func (s Schema[T]) DeleteBatched(rq *Request, condition rdb.Condition, batchSize uint, pause time.Duration) (int, error) {
+	return deleteBatchedAt[T](rq, condition, batchSize, pause, s.Name, s.Table)
+}
+
+// DeleteQuery at table, in chunks of batchSize rows until exhausted, return total rowsAffected
+func (s Schema[T]) DeleteBatchedAt(rq *Request, condition rdb.Condition, batchSize uint, pause time.Duration, table string) (int, error) {
+	return deleteBatchedAt[T](rq, condition, batchSize, pause, s.Name, table)
+}
+
+// Common: create and execute DeleteQuery at given table using condition;
+// limit = 0 deletes all matching rows, non-nil checker guards deletes outside of transactions
+func deleteAt[T any](rq *Request, condition rdb.Condition, limit uint, checker rdb.ResultChecker, name, table string, isTx bool) (int, error) {
 	// Check that condition is set
 	if condition == nil {
 		rq.AddLog("Delete condition is not set")
@@ -63,16 +85,24 @@ func deleteAt(rq *Request, condition rdb.Condition, name, table string, isTx boo
 	// Build DeleteQuery
 	q := rdb.NewDeleteQuery(table)
 	q.Where(condition)
+	q.Limit(limit)
+	if rq.DryRun {
+		return dryRunAt[T](rq, q, condition, limit, name, table, "delete")
+	}
 
 	// Execute DeleteQuery
 	var result *sql.Result
 	var err error
+	start := time.Now()
 	if isTx {
 		rq.AddTxStep(q)
 		result, err = rdb.ExecTx(q, rq.DBTx, rq.Checker)
+	} else if checker != nil {
+		result, err = rdb.ExecChecked(q, rq.DB, checker)
 	} else {
 		result, err = rdb.Exec(q, rq.DB)
 	}
+	observe(name, table, "delete", start, err)
 	if err != nil {
 		rq.AddFmtLog("Failed to delete %s", name)
 		rq.Status = Err500
@@ -85,3 +115,35 @@ func deleteAt(rq *Request, condition rdb.Condition, name, table string, isTx boo
 	}
 	return rowsAffected, nil
 }
+
+// Common: execute DeleteQuery at given table in chunks of batchSize rows, pausing between chunks,
+// until a chunk deletes less than batchSize rows; each chunk is committed on its own
+func deleteBatchedAt[T any](rq *Request, condition rdb.Condition, batchSize uint, pause time.Duration, name, table string) (int, error) {
+	// Check that batch size is set
+	if batchSize == 0 {
+		rq.AddLog("Delete batch size is not set")
+		rq.Status = Err500
+		return 0, fail.MissingParams
+	}
+	if rq.DryRun {
+		// Preview all matching rows, as nothing is deleted between chunks
+		return deleteAt[T](rq, condition, 0, nil, name, table, false)
+	}
+
+	total, numBatches := 0, 0
+	for {
+		rowsAffected, err := deleteAt[T](rq, condition, batchSize, nil, name, table, false)
+		total += rowsAffected
+		if err != nil {
+			rq.AddFmtLog("Deleted: %d %s before failed batch", total, name)
+			return total, err
+		}
+		numBatches++
+		if rowsAffected < int(batchSize) {
+			break
+		}
+		time.Sleep(pause)
+	}
+	rq.AddFmtLog("Deleted: %d %s in %d batches", total, name, numBatches)
+	return total, nil
+}
@@ -2,11 +2,16 @@ package ze
 
 import (
 	"database/sql"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
 
 	"github.com/roidaradal/fn/check"
 	"github.com/roidaradal/fn/dict"
 	"github.com/roidaradal/fn/dyn"
 	"github.com/roidaradal/fn/fail"
+	"github.com/roidaradal/fn/lang"
 	"github.com/roidaradal/rdb"
 )
 
@@ -48,26 +53,86 @@ func (s Schema[T]) FieldUpdates(rq *Request, oldItem *T, patchObject dict.Object
 
 // UpdateQuery at schema.Table
 func (s Schema[T]) Update(rq *Request, updates rdb.FieldUpdates, condition rdb.Condition) error {
-	return updateAt[T](rq, updates, condition, s.Name, s.Table, false)
+	return updateAt[T](rq, updates, condition, s.Name, s.updated, s.Table, false)
 }
 
 // UpdateQuery at table
 func (s Schema[T]) UpdateAt(rq *Request, updates rdb.FieldUpdates, condition rdb.Condition, table string) error {
-	return updateAt[T](rq, updates, condition, s.Name, table, false)
+	return updateAt[T](rq, updates, condition, s.Name, s.updated, table, false)
 }
 
 // UpdateQuery transaction at schema.Table
 func (s Schema[T]) UpdateTx(rqtx *Request, updates rdb.FieldUpdates, condition rdb.Condition) error {
-	return updateAt[T](rqtx, updates, condition, s.Name, s.Table, true)
+	return updateAt[T](rqtx, updates, condition, s.Name, s.updated, s.Table, true)
 }
 
 // UpdateQuery transaction at table
 func (s Schema[T]) UpdateTxAt(rqtx *Request, updates rdb.FieldUpdates, condition rdb.Condition, table string) error {
-	return updateAt[T](rqtx, updates, condition, s.Name, table, true)
+	return updateAt[T](rqtx, updates, condition, s.Name, s.updated, table, true)
+}
+
+// UpdateQuery at schema.Table: field = field + amount, fieldRef from schema.Ref
+func Increment[T any, V rdb.Number](s *Schema[T], rq *Request, condition rdb.Condition, fieldRef *V, amount V) error {
+	return incrementAt[T](rq, condition, fieldRef, amount, false, s.Name, s.updated, s.Table, false)
+}
+
+// UpdateQuery at table: field = field + amount, fieldRef from schema.Ref
+func IncrementAt[T any, V rdb.Number](s *Schema[T], rq *Request, condition rdb.Condition, fieldRef *V, amount V, table string) error {
+	return incrementAt[T](rq, condition, fieldRef, amount, false, s.Name, s.updated, table, false)
+}
+
+// UpdateQuery transaction at schema.Table: field = field + amount, fieldRef from schema.Ref
+func IncrementTx[T any, V rdb.Number](s *Schema[T], rqtx *Request, condition rdb.Condition, fieldRef *V, amount V) error {
+	return incrementAt[T](rqtx, condition, fieldRef, amount, false, s.Name, s.updated, s.Table, true)
+}
+
+// UpdateQuery transaction at table: field = field + amount, fieldRef from schema.Ref
+func IncrementTxAt[T any, V rdb.Number](s *Schema[T], rqtx *Request, condition rdb.Condition, fieldRef *V, amount V, table string) error {
+	return incrementAt[T](rqtx, condition, fieldRef, amount, false, s.Name, s.updated, table, true)
+}
+
+// UpdateQuery at schema.Table: field = field - amount, fieldRef from schema.Ref
+func Decrement[T any, V rdb.Number](s *Schema[T], rq *Request, condition rdb.Condition, fieldRef *V, amount V) error {
+	return incrementAt[T](rq, condition, fieldRef, amount, true, s.Name, s.updated, s.Table, false)
+}
+
+// UpdateQuery at table: field = field - amount, fieldRef from schema.Ref
+func DecrementAt[T any, V rdb.Number](s *Schema[T], rq *Request, condition rdb.Condition, fieldRef *V, amount V, table string) error {
+	return incrementAt[T](rq, condition, fieldRef, amount, true, s.Name, s.updated, table, false)
+}
+
+// UpdateQuery transaction at schema.Table: field = field - amount, fieldRef from schema.Ref
+func DecrementTx[T any, V rdb.Number](s *Schema[T], rqtx *Request, condition rdb.Condition, fieldRef *V, amount V) error {
+	return incrementAt[T](rqtx, condition, fieldRef, amount, true, s.Name, s.updated, s.Table, true)
+}
+
+// UpdateQuery transaction at table: field = field - amount, fieldRef from schema.Ref
+func DecrementTxAt[T any, V rdb.Number](s *Schema[T], rqtx *Request, condition rdb.Condition, fieldRef *V, amount V, table string) error {
+	return incrementAt[T](rqtx, condition, fieldRef, amount, true, s.Name, s.updated, table, true)
+}
+
+// BulkUpdateQuery at schema.Table: {ID => FieldUpdates}, each row gets its own new values
+func (s Schema[T]) UpdateMany(rq *Request, updates map[ID]rdb.FieldUpdates) error {
+	return updateManyAt[T](rq, updates, s.Name, s.updated, s.Table, false)
+}
+
+// BulkUpdateQuery at table: {ID => FieldUpdates}, each row gets its own new values
+func (s Schema[T]) UpdateManyAt(rq *Request, updates map[ID]rdb.FieldUpdates, table string) error {
+	return updateManyAt[T](rq, updates, s.Name, s.updated, table, false)
+}
+
+// BulkUpdateQuery transaction at schema.Table: {ID => FieldUpdates}, each row gets its own new values
+func (s Schema[T]) UpdateManyTx(rqtx *Request, updates map[ID]rdb.FieldUpdates) error {
+	return updateManyAt[T](rqtx, updates, s.Name, s.updated, s.Table, true)
+}
+
+// BulkUpdateQuery transaction at table: {ID => FieldUpdates}, each row gets its own new values
+func (s Schema[T]) UpdateManyTxAt(rqtx *Request, updates map[ID]rdb.FieldUpdates, table string) error {
+	return updateManyAt[T](rqtx, updates, s.Name, s.updated, table, true)
 }
 
 // Common: create and execute UpdateQuery at given table
-func updateAt[T any](rq *Request, updates rdb.FieldUpdates, condition rdb.Condition, name, table string, isTx bool) error {
+func updateAt[T any](rq *Request, updates rdb.FieldUpdates, condition rdb.Condition, name, updatedField, table string, isTx bool) error {
 	// Check that condition and updates are set
 	if condition == nil || updates == nil {
 		rq.AddLog("Condition/updates not set")
@@ -79,25 +144,154 @@ func updateAt[T any](rq *Request, updates rdb.FieldUpdates, condition rdb.Condit
 	q := rdb.NewUpdateQuery[T](table)
 	q.Where(condition)
 	q.Updates(updates)
+	if updatedField != "" && len(updates) > 0 && dict.NoKey(updates, updatedField) {
+		// Set UpdatedAt field to request time
+		field, _ := reflect.TypeFor[T]().FieldByName(updatedField)
+		q.Update(updatedField, timestampValue(field.Type, rq.Time()))
+	}
+	if rq.DryRun {
+		_, err := dryRunAt[T](rq, q, condition, 0, name, table, "update")
+		return err
+	}
 
 	// Execute UpdateQuery
 	var result *sql.Result
 	var err error
+	start := time.Now()
 	if isTx {
 		rq.AddTxStep(q)
 		result, err = rdb.ExecTx(q, rq.DBTx, rq.Checker)
 	} else {
 		result, err = rdb.Exec(q, rq.DB)
 	}
+	observe(name, table, "update", start, err)
 	if err != nil {
 		rq.AddFmtLog("Failed to update %s", name)
+		return classifyError[T](rq, err)
+	}
+
+	rowsAffected := rdb.RowsAffected(result)
+	if rowsAffected != 1 {
+		rq.AddFmtLog("Updated: %d %s", rowsAffected, name)
+	}
+	return nil
+}
+
+// Common: create and execute UpdateQuery that increments (or decrements) field at given table
+func incrementAt[T any, V rdb.Number](rq *Request, condition rdb.Condition, fieldRef *V, amount V, decrement bool, name, updatedField, table string, isTx bool) error {
+	// Check that condition and field are set
+	fieldName := rdb.Field(name, fieldRef)
+	if condition == nil || fieldName == "" {
+		rq.AddLog("Condition/field not set")
 		rq.Status = Err500
+		return fail.MissingParams
+	}
+
+	// Build UpdateQuery
+	q := rdb.NewUpdateQuery[T](table)
+	q.Where(condition)
+	if decrement {
+		rdb.UpdateDecrement(q, fieldRef, amount)
+	} else {
+		rdb.UpdateIncrement(q, fieldRef, amount)
+	}
+	if updatedField != "" && updatedField != fieldName {
+		// Set UpdatedAt field to request time
+		field, _ := reflect.TypeFor[T]().FieldByName(updatedField)
+		q.Update(updatedField, timestampValue(field.Type, rq.Time()))
+	}
+	if rq.DryRun {
+		_, err := dryRunAt[T](rq, q, condition, 0, name, table, lang.Ternary(decrement, "decrement", "increment"))
 		return err
 	}
 
+	// Execute UpdateQuery
+	var result *sql.Result
+	var err error
+	start := time.Now()
+	if isTx {
+		rq.AddTxStep(q)
+		result, err = rdb.ExecTx(q, rq.DBTx, rq.Checker)
+	} else {
+		result, err = rdb.Exec(q, rq.DB)
+	}
+	observe(name, table, lang.Ternary(decrement, "decrement", "increment"), start, err)
+	if err != nil {
+		rq.AddFmtLog("Failed to update %s.%s", name, fieldName)
+		return classifyError[T](rq, err)
+	}
+
 	rowsAffected := rdb.RowsAffected(result)
 	if rowsAffected != 1 {
 		rq.AddFmtLog("Updated: %d %s", rowsAffected, name)
 	}
 	return nil
 }
+
+// Common: create and execute BulkUpdateQuery at given table, in batches;
+// if not in a transaction, multiple batches are executed in their own transaction (all or nothing)
+func updateManyAt[T any](rq *Request, updates map[ID]rdb.FieldUpdates, name, updatedField, table string, isTx bool) error {
+	// Check that updates are set
+	if updates == nil {
+		rq.AddLog("Updates not set")
+		rq.Status = Err500
+		return fail.MissingParams
+	}
+
+	// Build BulkUpdateQuery, in ID order
+	q := rdb.NewBulkUpdateKeyQuery[T, ID](table, idField)
+	var updatedType reflect.Type
+	if updatedField != "" {
+		field, _ := reflect.TypeFor[T]().FieldByName(updatedField)
+		updatedType = field.Type
+	}
+	ids := make([]any, 0, len(updates))
+	for _, id := range slices.Sorted(maps.Keys(updates)) {
+		itemUpdates := updates[id]
+		if len(itemUpdates) == 0 {
+			continue // skip items without updates
+		}
+		ids = append(ids, id)
+		q.Updates(id, itemUpdates)
+		if updatedType != nil && dict.NoKey(itemUpdates, updatedField) {
+			// Set UpdatedAt field to request time
+			q.Update(id, updatedField, timestampValue(updatedType, rq.Time()))
+		}
+	}
+	numItems := len(ids)
+	if numItems == 0 {
+		return nil // nothing to update
+	}
+	if rq.DryRun {
+		condition := rdb.FieldCondition(name, idField, rdb.OpIn, ids...)
+		_, err := dryRunAt[T](rq, q, condition, 0, name, table, "updateMany")
+		return err
+	}
+
+	// Execute BulkUpdateQuery in batches
+	var result *rdb.BatchResult
+	var err error
+	start := time.Now()
+	switch {
+	case isTx:
+		rq.AddTxStep(q)
+		result, err = rdb.ExecBatchesTx(q, rq.DBTx, rq.Checker)
+	case len(q.Batches()) > 1:
+		err = runInTransaction(rq, func(dbtx *sql.Tx) error {
+			result, err = rdb.ExecBatchesTx(q, dbtx, rdb.AssertNothing)
+			return err
+		})
+	default:
+		result, err = rdb.ExecBatches(q, rq.DB)
+	}
+	observe(name, table, "updateMany", start, err)
+	if err != nil {
+		rq.AddFmtLog("Failed to update %d %s", numItems, name)
+		return classifyError[T](rq, err)
+	}
+
+	if result.RowsAffected != numItems {
+		rq.AddFmtLog("Updated: %d of %d %s", result.RowsAffected, numItems, name)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package ze
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/roidaradal/fn/fail"
 	"github.com/roidaradal/rdb"
@@ -61,15 +62,21 @@ func setFlagAt[T any](rq *Request, condition rdb.Condition, field *bool, flag bo
 	q.Where(condition)
 	q.Limit(1)
 	rdb.Update(q, field, flag)
+	if rq.DryRun {
+		_, err := dryRunAt[T](rq, q, condition, 1, name, table, "setFlag")
+		return err
+	}
 
 	// Execute UpdateQuery
 	var err error
+	start := time.Now()
 	if isTx {
 		rq.AddTxStep(q)
 		_, err = rdb.ExecTx(q, rq.DBTx, rq.Checker)
 	} else {
 		_, err = rdb.Exec(q, rq.DB)
 	}
+	observe(name, table, "setFlag", start, err)
 	if err != nil {
 		rq.AddFmtLog("Failed to update %s", name)
 		rq.Status = Err500
@@ -91,10 +98,15 @@ func setFlagsAt[T any](rq *Request, condition rdb.Condition, field *bool, flag b
 	q := rdb.NewUpdateQuery[T](table)
 	q.Where(condition)
 	rdb.Update(q, field, flag)
+	if rq.DryRun {
+		_, err := dryRunAt[T](rq, q, condition, 0, name, table, "setFlags")
+		return err
+	}
 
 	// Execute UpdateQuery
 	var result *sql.Result
 	var err error
+	start := time.Now()
 	if isTx {
 		rq.AddTxStep(q)
 		checker := rdb.AssertRowsAffected(numItems)
@@ -102,6 +114,7 @@ func setFlagsAt[T any](rq *Request, condition rdb.Condition, field *bool, flag b
 	} else {
 		result, err = rdb.Exec(q, rq.DB)
 	}
+	observe(name, table, "setFlags", start, err)
 	if err != nil {
 		rq.AddFmtLog("Failed to update %s", name)
 		rq.Status = Err500
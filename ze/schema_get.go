@@ -1,6 +1,8 @@
 package ze
 
 import (
+	"time"
+
 	"github.com/roidaradal/fn/dict"
 	"github.com/roidaradal/fn/fail"
 	"github.com/roidaradal/fn/list"
@@ -75,6 +77,16 @@ func (s Schema[T]) GetRowsOnlyAt(rq *Request, condition rdb.Condition, table str
 	return pruneRows(items, err, fieldNames)
 }
 
+// SelectRowsQuery at schema.Table, using filter's condition, ordering and pagination
+func (s Schema[T]) GetFilteredRows(rq *Request, filter *Filter) ([]*T, error) {
+	return selectFilteredRowsAt(rq, filter, s.Table, &s)
+}
+
+// SelectRowsQuery at table, using filter's condition, ordering and pagination
+func (s Schema[T]) GetFilteredRowsAt(rq *Request, filter *Filter, table string) ([]*T, error) {
+	return selectFilteredRowsAt(rq, filter, table, &s)
+}
+
 // SelectRowsQuery (all) at schema.Table
 func (s Schema[T]) GetAllRows(rq *Request) ([]*T, error) {
 	return selectRowsAt(rq, nil, s.Table, &s)
@@ -109,7 +121,11 @@ func selectRowAt[T any](rq *Request, condition rdb.Condition, table string, sche
 	// Build SelectRowQuery and execute
 	q := rdb.NewFullSelectRowQuery(table, schema.Reader)
 	q.Where(condition)
-	item, err := q.QueryRow(rq.DB)
+	db := rq.ReadDB()
+	start := time.Now()
+	item, err := q.QueryRow(db)
+	observe(schema.Name, table, "get", start, err)
+	rq.observeRead(db, start, err)
 	if err != nil {
 		rq.Status = Err500
 		return nil, err
@@ -125,7 +141,29 @@ func selectRowsAt[T any](rq *Request, condition rdb.Condition, table string, sch
 	if condition != nil {
 		q.Where(condition)
 	}
-	items, err := q.Query(rq.DB)
+	db := rq.ReadDB()
+	start := time.Now()
+	items, err := q.Query(db)
+	observe(schema.Name, table, "getRows", start, err)
+	rq.observeRead(db, start, err)
+	if err != nil {
+		rq.Status = Err500
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Common: create and execute filtered SelectRowsQuery at given table
+func selectFilteredRowsAt[T any](rq *Request, filter *Filter, table string, schema *Schema[T]) ([]*T, error) {
+	// Build SelectRowsQuery, apply filter and execute
+	q := rdb.NewFullSelectRowsQuery(table, schema.Reader)
+	ApplyFilter(q, filter)
+	db := rq.ReadDB()
+	start := time.Now()
+	items, err := q.Query(db)
+	observe(schema.Name, table, "getFilteredRows", start, err)
+	rq.observeRead(db, start, err)
 	if err != nil {
 		rq.Status = Err500
 		return nil, err
@@ -28,6 +28,11 @@ func (s Schema[T]) GetOrCreateTx(rqtx *Request, cfg *GetOrCreateParams[T]) (*T,
 // Finally, get the item using preCondition and postCondition
 func getOrCreate[T any](rq *Request, cfg *GetOrCreateParams[T], schema *Schema[T], isTx bool) (*T, error) {
 	rqtx := rq
+	// Read from primary DB, as the item may have just been created
+	readPrimary := rq.ReadPrimary
+	rq.ReadPrimary = true
+	defer func() { rq.ReadPrimary = readPrimary }()
+
 	// Check if item exists
 	numRows, err := schema.Count(rq, cfg.PreCondition)
 	if err != nil {
@@ -1,26 +1,34 @@
 package ze
 
-import "github.com/roidaradal/rdb"
+import (
+	"time"
+
+	"github.com/roidaradal/rdb"
+)
 
 // SumQuery at schema.Table
 func (s Schema[T]) Sum(rq *Request, columns []string, reader rdb.RowReader[T], condition rdb.Condition) (*T, error) {
-	return sumAt(rq, columns, reader, condition, s.Table)
+	return sumAt(rq, columns, reader, condition, s.Name, s.Table)
 }
 
 // SumQuery at table
 func (s Schema[T]) SumAt(rq *Request, columns []string, reader rdb.RowReader[T], condition rdb.Condition, table string) (*T, error) {
-	return sumAt(rq, columns, reader, condition, table)
+	return sumAt(rq, columns, reader, condition, s.Name, table)
 }
 
 // Common: create and execute SumQuery at given table
-func sumAt[T any](rq *Request, columns []string, reader rdb.RowReader[T], condition rdb.Condition, table string) (*T, error) {
+func sumAt[T any](rq *Request, columns []string, reader rdb.RowReader[T], condition rdb.Condition, name, table string) (*T, error) {
 	// Build SumQuery and execute
 	q := rdb.NewSumQuery(table, reader)
 	q.Columns(columns)
 	if condition != nil {
 		q.Where(condition)
 	}
-	sum, err := q.Sum(rq.DB)
+	db := rq.ReadDB()
+	start := time.Now()
+	sum, err := q.Sum(db)
+	observe(name, table, "sum", start, err)
+	rq.observeRead(db, start, err)
 	if err != nil {
 		rq.Status = Err500
 		return nil, err
@@ -1,6 +1,8 @@
 package ze
 
 import (
+	"time"
+
 	"github.com/roidaradal/fn/fail"
 	"github.com/roidaradal/rdb"
 )
@@ -89,17 +91,24 @@ func toggleAt[T any](rq *Request, p *toggleParams, name, table string, byID bool
 		condition1 = rdb.Equal(&item.Code, p.code)
 	}
 	condition2 := rdb.Equal(&item.IsActive, !p.isActive)
-	q.Where(rdb.And(condition1, condition2))
+	condition := rdb.And(condition1, condition2)
+	q.Where(condition)
 	rdb.Update(q, &item.IsActive, p.isActive)
+	if rq.DryRun {
+		_, err := dryRunAt[T](rq, q, condition, 0, name, table, "toggle")
+		return err
+	}
 
 	// Execute UpdateQuery
 	var err error
+	start := time.Now()
 	if isTx {
 		rq.AddTxStep(q)
 		_, err = rdb.ExecTx(q, rq.DBTx, rq.Checker)
 	} else {
 		_, err = rdb.Exec(q, rq.DB)
 	}
+	observe(name, table, "toggle", start, err)
 	if err != nil {
 		rq.AddFmtLog("Failed to toggle %s", name)
 		rq.Status = Err500
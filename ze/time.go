@@ -0,0 +1,81 @@
+package ze
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/roidaradal/rdb"
+)
+
+var (
+	timeZone       *time.Location = time.Local            // time zone of Now, Date and DateTime values
+	dateFormat     string         = "2006-01-02"          // database format of Date values
+	dateTimeFormat string         = "2006-01-02 15:04:05" // database format of DateTime values
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// Set time zone used for Now, Date and DateTime values
+func SetTimeZone(location *time.Location) {
+	if location != nil {
+		timeZone = location
+	}
+}
+
+// Set database formats of Date and DateTime values
+func SetTimeFormats(date, dateTime string) {
+	if date != "" {
+		dateFormat = date
+	}
+	if dateTime != "" {
+		dateTimeFormat = dateTime
+	}
+}
+
+// Current time, in the configured time zone
+func Now() time.Time {
+	return time.Now().In(timeZone)
+}
+
+// Format time as Date, in the configured time zone
+func FormatDate(t time.Time) Date {
+	return t.In(timeZone).Format(dateFormat)
+}
+
+// Format time as DateTime, in the configured time zone
+func FormatDateTime(t time.Time) DateTime {
+	return t.In(timeZone).Format(dateTimeFormat)
+}
+
+// Parse Date in the configured time zone
+func ParseDate(date Date) (time.Time, error) {
+	return time.ParseInLocation(dateFormat, date, timeZone)
+}
+
+// Parse DateTime in the configured time zone
+func ParseDateTime(dateTime DateTime) (time.Time, error) {
+	return time.ParseInLocation(dateTimeFormat, dateTime, timeZone)
+}
+
+// Condition: start <= field < end, for time.Time or Date/DateTime fields
+func Within[V time.Time | string](fieldRef *V, start, end V) rdb.Condition {
+	return rdb.And(
+		rdb.GreaterEqual(fieldRef, start),
+		rdb.Less(fieldRef, end),
+	)
+}
+
+// Condition: field is within the day of given time, for time.Time fields
+func OnDay(fieldRef *time.Time, day time.Time) rdb.Condition {
+	day = day.In(timeZone)
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, timeZone)
+	return Within(fieldRef, start, start.AddDate(0, 0, 1))
+}
+
+// Value of timestamp field with given type: time.Time or DateTime
+func timestampValue(fieldType reflect.Type, t time.Time) any {
+	if fieldType == timeType {
+		return t
+	}
+	return FormatDateTime(t)
+}
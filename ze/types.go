@@ -1,6 +1,6 @@
 package ze
 
-import "github.com/roidaradal/fn/clock"
+import "time"
 
 type (
 	ID       = uint
@@ -8,6 +8,14 @@ type (
 	DateTime = string
 )
 
+// Field name of UniqueItem's ID
+const idField string = "ID"
+
+// Item that can receive its generated ID
+type idSetter interface {
+	SetID(ID)
+}
+
 // Embeddable ID property
 type UniqueItem struct {
 	ID ID `json:"-"`
@@ -59,6 +67,33 @@ func (x CreatedItem) GetDateTime() DateTime {
 	return x.CreatedAt
 }
 
+// Embeddable CreatedAt property, as time.Time
+type CreatedTimeItem struct {
+	CreatedAt time.Time
+}
+
+func (x CreatedTimeItem) GetTime() time.Time {
+	return x.CreatedAt
+}
+
+// Embeddable UpdatedAt property, set on Schema.Update
+type UpdatedItem struct {
+	UpdatedAt DateTime `rdb:"updated"`
+}
+
+func (x UpdatedItem) GetUpdatedDateTime() DateTime {
+	return x.UpdatedAt
+}
+
+// Embeddable UpdatedAt property as time.Time, set on Schema.Update
+type UpdatedTimeItem struct {
+	UpdatedAt time.Time `rdb:"updated"`
+}
+
+func (x UpdatedTimeItem) GetUpdatedTime() time.Time {
+	return x.UpdatedAt
+}
+
 // Embeddable IsActive property
 type ActiveItem struct {
 	IsActive bool
@@ -101,9 +136,23 @@ func (x *ItemCoded) Initialize() {
 	x.AutoItem.Initialize()
 }
 
+// ID, IsActive, CreatedAt (time.Time)
+type AutoTimeItem struct {
+	UniqueItem
+	CreatedTimeItem
+	ActiveItem
+}
+
 // Initialize the ID, CratedAt, IsActive to default values
 func (x *AutoItem) Initialize() {
 	x.ID = 0 // for auto-increment
-	x.CreatedAt = clock.DateTimeNow()
+	x.CreatedAt = FormatDateTime(Now())
+	x.IsActive = true
+}
+
+// Initialize the ID, CreatedAt, IsActive to default values
+func (x *AutoTimeItem) Initialize() {
+	x.ID = 0 // for auto-increment
+	x.CreatedAt = Now()
 	x.IsActive = true
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/roidaradal/rdb"
 )
@@ -16,6 +17,8 @@ const (
 	Err401 = http.StatusUnauthorized        // unauthenticated
 	Err403 = http.StatusForbidden           // unauthorized
 	Err404 = http.StatusNotFound            // not found
+	Err409 = http.StatusConflict            // conflict (duplicate)
+	Err422 = http.StatusUnprocessableEntity // constraint violation
 	Err429 = http.StatusTooManyRequests     // rate limiting
 	Err500 = http.StatusInternalServerError // server-side error
 )
@@ -28,6 +31,7 @@ var (
 	errNoDBConnection = errors.New("no db connection")
 	errNoDBTx         = errors.New("no db transaction")
 	errNoLastInsertID = errors.New("no last insert id")
+	errNoIDColumn     = errors.New("no id column")
 	errNoRowsInserted = errors.New("no rows inserted")
 )
 
@@ -87,6 +91,12 @@ func AddSharedSchema[T any](item *T, errs []error) *Schema[T] {
 	return schema
 }
 
+// Record Schema operation metrics
+func observe(name, table, operation string, start time.Time, err error) {
+	labels := rdb.MetricLabels{Schema: name, Table: table, Operation: operation}
+	rdb.ObserveSchema(labels, start, err)
+}
+
 // Get Item reference object
 func ItemsRef() *Item {
 	if Items == nil {